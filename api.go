@@ -1,18 +1,26 @@
 package goex
 
 import (
+	"time"
+
 	"github.com/shadowors/goex/v2/model"
 )
 
 // IPubRest is a public interface that does not require authorization."
 type IPubRest interface {
 	GetName() string //获取交易所名字/域名
+	// GetServerTime 服务器时间(毫秒), 用于util.NewClock校正签名时间
+	GetServerTime() (serverTime int64, responseBody []byte, err error)
 	// GetDepth
 	//    bids: 降序
 	//    asks: 升序
 	GetDepth(pair model.CurrencyPair, limit int, opt ...model.OptionParameter) (depth *model.Depth, responseBody []byte, err error)
 	GetTicker(pair model.CurrencyPair, opt ...model.OptionParameter) (ticker *model.Ticker, responseBody []byte, err error)
+	// GetTickers 一次返回全部交易对行情, key为Symbol; instType仅okx有效: SPOT,SWAP,FUTURES
+	GetTickers(instType string, opt ...model.OptionParameter) (tickers map[string]model.Ticker, responseBody []byte, err error)
 	GetKline(pair model.CurrencyPair, period model.KlinePeriod, opt ...model.OptionParameter) (klines []model.Kline, responseBody []byte, err error)
+	// GetTrades 最近公共成交, 按时间升序, Side为主动成交方向
+	GetTrades(pair model.CurrencyPair, limit int, opt ...model.OptionParameter) (trades []model.Trade, responseBody []byte, err error)
 	GetExchangeInfo() (map[string]model.CurrencyPair, []byte, error)
 	// NewCurrencyPair 同时支持现货和期货
 	//@parameter
@@ -22,6 +30,12 @@ type IPubRest interface {
 	NewCurrencyPair(baseSym, quoteSym string, opts ...model.OptionParameter) (model.CurrencyPair, error)
 }
 
+// IHistoryTradesRest 历史公共成交分页查询
+type IHistoryTradesRest interface {
+	// GetHistoryTrades 返回成交ID小于afterId的成交, 按时间升序; afterId为空时返回最近的成交
+	GetHistoryTrades(pair model.CurrencyPair, afterId string, limit int, opt ...model.OptionParameter) (trades []model.Trade, responseBody []byte, err error)
+}
+
 // IPrvRest is a private interface specification that requires authorization to call.
 type IPrvRest interface {
 	GetAccount(coin string) (map[string]model.Account, []byte, error)
@@ -35,6 +49,46 @@ type IPrvRest interface {
 	GetPendingOrders(pair model.CurrencyPair, opt ...model.OptionParameter) (orders []model.Order, responseBody []byte, err error)
 	GetHistoryOrders(pair model.CurrencyPair, opt ...model.OptionParameter) (orders []model.Order, responseBody []byte, err error)
 	CancelOrder(pair model.CurrencyPair, id string, opt ...model.OptionParameter) (responseBody []byte, err error)
+	//CreateOrders 批量下单, 超过交易所单次数量上限时自动拆分成多次请求
+	//@returns
+	//  results      与reqs一一对应的结果, 单个订单的失败原因见OrderResult.Err
+	//  responseBody 最后一次请求交易所接口返回的原始字节数据
+	//  err          请求失败的错误
+	CreateOrders(reqs []model.OrderRequest, opt ...model.OptionParameter) (results []model.OrderResult, responseBody []byte, err error)
+	//CancelOrders 批量撤单, 规则同CreateOrders
+	CancelOrders(pair model.CurrencyPair, ids []string, opt ...model.OptionParameter) (results []model.OrderResult, responseBody []byte, err error)
+	//AmendOrder 修改挂单的数量和价格, newQty为包含已成交部分的总数量, newQty/newPrice为0时不修改
+	//交易所不支持改单时撤单后按剩余数量重新下单, 使用的方式见AmendResult.Mode
+	AmendOrder(pair model.CurrencyPair, id string, newQty, newPrice float64, opt ...model.OptionParameter) (result *model.AmendResult, responseBody []byte, err error)
+	//CancelAllOrders 撤销交易对的所有挂单
+	CancelAllOrders(pair model.CurrencyPair, opt ...model.OptionParameter) (responseBody []byte, err error)
+	//GetFills 获取成交明细
+	//@parameter
+	//  since 开始时间(毫秒), 0为不限制
+	//  limit 返回数量, 0为交易所默认数量
+	GetFills(pair model.CurrencyPair, since int64, limit int, opt ...model.OptionParameter) (fills []model.Trade, responseBody []byte, err error)
+}
+
+// ICancelAllAfter 倒计时撤单(dead man's switch), 超时未刷新时交易所撤销所有挂单.
+// timeout为0时取消倒计时; 部分交易所按账户生效, 会忽略pair
+type ICancelAllAfter interface {
+	CancelAllAfter(pair model.CurrencyPair, timeout time.Duration, opt ...model.OptionParameter) (responseBody []byte, err error)
+}
+
+// IAlgoOrderRest 条件单(止损/止盈/追踪止损/OCO), 订单ID与普通订单相互独立
+type IAlgoOrderRest interface {
+	CreateAlgoOrder(req model.AlgoOrderRequest, opt ...model.OptionParameter) (order *model.Order, responseBody []byte, err error)
+	GetAlgoOrderInfo(pair model.CurrencyPair, id string, opt ...model.OptionParameter) (order *model.Order, responseBody []byte, err error)
+	GetPendingAlgoOrders(pair model.CurrencyPair, opt ...model.OptionParameter) (orders []model.Order, responseBody []byte, err error)
+	CancelAlgoOrder(pair model.CurrencyPair, id string, opt ...model.OptionParameter) (responseBody []byte, err error)
+}
+
+// ITransferRest 同一用户资金账户、交易账户、合约账户之间的资金划转, 交易所不支持的账户类型返回错误
+type ITransferRest interface {
+	//Transfer 划转, 返回的Transfer.Id用于GetTransfer查询状态
+	Transfer(ccy string, amount float64, from, to model.AccountType, opt ...model.OptionParameter) (transfer *model.Transfer, responseBody []byte, err error)
+	//GetTransfer 查询划转状态, binance需要from/to确定划转类型, 其他交易所忽略
+	GetTransfer(id string, from, to model.AccountType, opt ...model.OptionParameter) (transfer *model.Transfer, responseBody []byte, err error)
 }
 
 type ISpotPrvRest interface {
@@ -59,4 +113,16 @@ type IFuturesPrvRest interface {
 	//	responseBody 交易所接口返回的原始字节数据
 	//	err          错误
 	GetPositions(pair model.CurrencyPair, opts ...model.OptionParameter) (positions []model.FuturesPosition, responseBody []byte, err error)
+	//GetLeverage 获取当前杠杆倍数
+	GetLeverage(pair model.CurrencyPair, marginMode model.MarginMode, opts ...model.OptionParameter) (lever float64, responseBody []byte, err error)
+	//SetLeverage 设置杠杆倍数
+	SetLeverage(pair model.CurrencyPair, lever float64, marginMode model.MarginMode, opts ...model.OptionParameter) (responseBody []byte, err error)
+	//SetMarginMode 切换逐仓/全仓, 按订单指定保证金模式的交易所返回错误
+	SetMarginMode(pair model.CurrencyPair, mode model.MarginMode, opts ...model.OptionParameter) (responseBody []byte, err error)
+	//SetPositionMode 切换单向/双向持仓, 按账户生效
+	SetPositionMode(mode model.PositionMode, opts ...model.OptionParameter) (responseBody []byte, err error)
+	//AdjustMargin 逐仓仓位增加(amount>0)或减少(amount<0)保证金
+	//@parameter
+	//	posSide Futures_OpenBuy: 多仓, Futures_OpenSell: 空仓, 单向持仓传空
+	AdjustMargin(pair model.CurrencyPair, posSide model.OrderSide, amount float64, opts ...model.OptionParameter) (responseBody []byte, err error)
 }
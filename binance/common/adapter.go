@@ -1,8 +1,10 @@
 package common
 
 import (
+	"fmt"
 	"github.com/shadowors/goex/v2/logger"
 	"github.com/shadowors/goex/v2/model"
+	"github.com/spf13/cast"
 	"net/url"
 )
 
@@ -86,6 +88,30 @@ func AdaptStringToFuturesOrderSide(side, positionSide string) model.OrderSide {
 	return model.OrderSide(side)
 }
 
+// AdaptBuyerMakerToSide 买方为maker时主动成交方向为卖
+func AdaptBuyerMakerToSide(buyerMaker bool) model.OrderSide {
+	if buyerMaker {
+		return model.Spot_Sell
+	}
+	return model.Spot_Buy
+}
+
+// AdaptAggTradesPageParams 归集成交ID连续递增, 由afterId向前推算fromId实现向前翻页
+func AdaptAggTradesPageParams(params *url.Values, afterId string, limit int) {
+	if limit <= 0 || limit > 1000 {
+		limit = 1000
+	}
+	if afterId != "" {
+		fromId := cast.ToInt64(afterId) - int64(limit)
+		if fromId < 0 {
+			limit += int(fromId)
+			fromId = 0
+		}
+		params.Set("fromId", fmt.Sprint(fromId))
+	}
+	params.Set("limit", fmt.Sprint(limit))
+}
+
 func AdaptStringToOrderType(ty string) model.OrderType {
 	switch ty {
 	case "LIMIT":
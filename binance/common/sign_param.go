@@ -2,16 +2,49 @@ package common
 
 import (
 	"fmt"
-	"github.com/shadowors/goex/v2/util"
+	"github.com/shadowors/goex/v2/options"
+	"github.com/shadowors/goex/v2/signer"
 	"net/url"
-	"time"
 )
 
-func SignParams(params *url.Values, secret string) {
-	timestamp := time.Now().UnixMilli()
+func SignParams(params *url.Values, apiOpts options.ApiOptions) error {
+	s, err := NewSigner(apiOpts)
+	if err != nil {
+		return err
+	}
+
+	timestamp := apiOpts.Now().UnixMilli()
 	params.Set("timestamp", fmt.Sprint(timestamp))
 	params.Set("recvWindow", "6000")
+	if apiOpts.RecvWindow > 0 {
+		params.Set("recvWindow", fmt.Sprint(apiOpts.RecvWindow.Milliseconds()))
+	}
 	payload := params.Encode()
-	sign, _ := util.HmacSHA256Sign(secret, payload)
+	sign, err := s.Sign(payload)
+	if err != nil {
+		return err
+	}
 	params.Set("signature", sign)
+	return nil
+}
+
+// SessionLogonParams WebSocket API session.logon请求参数(apiKey,timestamp,recvWindow,signature), 由DialWsApi发送.
+// 仅支持Ed25519密钥, 自定义Signer需实现signer.KeyTyper(如RemoteSigner.WithKeyType)并返回KeyType_Ed25519
+func SessionLogonParams(apiOpts options.ApiOptions) (url.Values, error) {
+	s, err := NewSigner(apiOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyType := signer.TypeOf(s); keyType != signer.KeyType_Ed25519 {
+		return nil, fmt.Errorf("binance session.logon requires an Ed25519 key, got signer key type %q", keyType)
+	}
+
+	params := url.Values{}
+	params.Set("apiKey", apiOpts.Key)
+	if err = SignParams(&params, apiOpts); err != nil {
+		return nil, err
+	}
+
+	return params, nil
 }
@@ -0,0 +1,45 @@
+package common
+
+import (
+	"os"
+	"sync"
+
+	"github.com/shadowors/goex/v2/options"
+	"github.com/shadowors/goex/v2/signer"
+)
+
+var keySigners sync.Map //私钥解析结果缓存, key: PrivateKeyFile+PrivateKey
+
+// NewSigner 优先使用ApiOptions.Signer; 设置了PrivateKey/PrivateKeyFile时按私钥类型选择Ed25519或RSA签名, 否则使用Secret做HMAC签名
+func NewSigner(apiOpts options.ApiOptions) (signer.Signer, error) {
+	if apiOpts.Signer != nil {
+		return apiOpts.Signer, nil
+	}
+
+	if apiOpts.PrivateKey == "" && apiOpts.PrivateKeyFile == "" {
+		return signer.NewHmacSHA256Hex(apiOpts.Secret), nil
+	}
+
+	cacheKey := apiOpts.PrivateKeyFile + apiOpts.PrivateKey
+	if s, ok := keySigners.Load(cacheKey); ok {
+		return s.(signer.Signer), nil
+	}
+
+	pemData := []byte(apiOpts.PrivateKey)
+	if apiOpts.PrivateKeyFile != "" {
+		var err error
+		pemData, err = os.ReadFile(apiOpts.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s, err := signer.NewKeySigner(pemData)
+	if err != nil {
+		return nil, err
+	}
+
+	keySigners.Store(cacheKey, s)
+
+	return s, nil
+}
@@ -0,0 +1,129 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/shadowors/goex/v2/options"
+	"github.com/spf13/cast"
+	"golang.org/x/net/websocket"
+)
+
+const wsApiTimeout = 10 * time.Second
+
+// WsApi Binance WebSocket API会话, 连接后以session.logon认证, 之后同一连接上的请求无需再签名
+//
+//	ws, _, err := goex.Binance.Spot.NewPrvApi(options.WithApiKey(key), options.WithPrivateKeyFile("ed25519.pem")).NewWsApi()
+//	defer ws.Close()
+//	data, err := ws.Request("account.status", nil)
+type WsApi struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+	seq  int64
+}
+
+type wsApiRequest struct {
+	Id     string         `json:"id"`
+	Method string         `json:"method"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+type wsApiResponse struct {
+	Id     string          `json:"id"`
+	Status int             `json:"status"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	} `json:"error"`
+}
+
+// DialWsApi 连接endpoint(UriOptions.WsPrivateEndpoint)并发送session.logon, 返回logon的result
+func DialWsApi(endpoint string, apiOpts options.ApiOptions) (*WsApi, []byte, error) {
+	params, err := SessionLogonParams(apiOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := websocket.Dial(endpoint, "", "https://www.binance.com")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ws := &WsApi{conn: conn}
+	data, err := ws.Request("session.logon", params)
+	if err != nil {
+		conn.Close()
+		return nil, data, fmt.Errorf("binance session.logon: %w", err)
+	}
+
+	return ws, data, nil
+}
+
+// Request 发送请求并等待相同id的响应, 返回result原始数据; status不为200时返回错误
+func (ws *WsApi) Request(method string, params url.Values) ([]byte, error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	ws.seq++
+	req := wsApiRequest{Id: fmt.Sprint(ws.seq), Method: method, Params: adaptWsApiParams(params)}
+
+	if err := ws.conn.SetDeadline(time.Now().Add(wsApiTimeout)); err != nil {
+		return nil, err
+	}
+
+	if err := websocket.JSON.Send(ws.conn, req); err != nil {
+		return nil, err
+	}
+
+	for {
+		var data []byte
+		if err := websocket.Message.Receive(ws.conn, &data); err != nil {
+			return nil, err
+		}
+
+		var resp wsApiResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return data, err
+		}
+		if resp.Id != req.Id {
+			continue
+		}
+
+		if resp.Status != 200 {
+			if resp.Error != nil {
+				return data, fmt.Errorf("status %d, code %d: %s", resp.Status, resp.Error.Code, resp.Error.Msg)
+			}
+			return data, errors.New(string(data))
+		}
+
+		return resp.Result, nil
+	}
+}
+
+func (ws *WsApi) Close() error {
+	return ws.conn.Close()
+}
+
+// adaptWsApiParams WebSocket API参数为json对象, timestamp和recvWindow需要为数字
+func adaptWsApiParams(params url.Values) map[string]any {
+	if len(params) == 0 {
+		return nil
+	}
+
+	ret := make(map[string]any, len(params))
+	for k := range params {
+		switch k {
+		case "timestamp", "recvWindow":
+			ret[k] = cast.ToInt64(params.Get(k))
+		default:
+			ret[k] = params.Get(k)
+		}
+	}
+
+	return ret
+}
@@ -1 +1,49 @@
 package dapi
+
+import (
+	"github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/options"
+)
+
+// DApi 币本位(COIN-M)合约, 下单数量单位为张, 面值见CurrencyPair.ContractVal(计价币USD)
+type DApi struct {
+	currencyPairM map[string]model.CurrencyPair
+
+	UriOpts       options.UriOptions
+	UnmarshalOpts options.UnmarshalerOptions
+}
+
+func NewDApi() *DApi {
+	d := &DApi{
+		UriOpts: options.UriOptions{
+			Endpoint:                 "https://dapi.binance.com",
+			WsEndpoint:               "wss://dstream.binance.com/ws",
+			GetExchangeInfoUri:       "/dapi/v1/exchangeInfo",
+			GetServerTimeUri:         "/dapi/v1/time",
+			GetFundingRateUri:        "/dapi/v1/premiumIndex",
+			GetFundingRateHistoryUri: "/dapi/v1/fundingRate",
+		},
+		UnmarshalOpts: options.UnmarshalerOptions{
+			GetExchangeInfoResponseUnmarshaler:       UnmarshalGetExchangeInfoResponse,
+			GetServerTimeResponseUnmarshaler:         UnmarshalGetServerTimeResponse,
+			GetFundingRateResponseUnmarshaler:        UnmarshalGetFundingRateResponse,
+			GetFundingRateHistoryResponseUnmarshaler: UnmarshalGetFundingRateHistoryResponse,
+		},
+	}
+
+	return d
+}
+
+func (d *DApi) WithUriOption(opts ...options.UriOption) *DApi {
+	for _, opt := range opts {
+		opt(&d.UriOpts)
+	}
+	return d
+}
+
+func (d *DApi) WithUnmarshalOption(opts ...options.UnmarshalerOption) *DApi {
+	for _, opt := range opts {
+		opt(&d.UnmarshalOpts)
+	}
+	return d
+}
@@ -1 +1,103 @@
 package dapi
+
+import (
+	"errors"
+	"fmt"
+	. "github.com/shadowors/goex/v2/httpcli"
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/util"
+	"net/http"
+	"net/url"
+)
+
+func (d *DApi) DoNoAuthRequest(httpMethod, reqUrl string, params *url.Values) ([]byte, []byte, error) {
+	if http.MethodGet == httpMethod {
+		reqUrl += "?" + params.Encode()
+	}
+
+	responseBody, err := Cli.DoRequest(httpMethod, reqUrl, "", nil)
+
+	return responseBody, responseBody, err
+}
+
+func (d *DApi) GetName() string {
+	return "binance.com"
+}
+
+// GetServerTime 服务器时间(毫秒)
+func (d *DApi) GetServerTime() (int64, []byte, error) {
+	data, body, err := d.DoNoAuthRequest(http.MethodGet, d.UriOpts.Endpoint+d.UriOpts.GetServerTimeUri, &url.Values{})
+	if err != nil {
+		return 0, body, err
+	}
+
+	ts, err := d.UnmarshalOpts.GetServerTimeResponseUnmarshaler(data)
+	return ts, body, err
+}
+
+func (d *DApi) GetExchangeInfo() (map[string]model.CurrencyPair, []byte, error) {
+	data, body, err := d.DoNoAuthRequest(http.MethodGet, d.UriOpts.Endpoint+d.UriOpts.GetExchangeInfoUri, &url.Values{})
+	if err != nil {
+		logger.Errorf("[GetExchangeInfo] http request error, body: %s", string(body))
+		return nil, body, err
+	}
+
+	m, err := d.UnmarshalOpts.GetExchangeInfoResponseUnmarshaler(data)
+	if err != nil {
+		logger.Errorf("[GetExchangeInfo] unmarshaler data error, err: %s", err.Error())
+		return nil, body, err
+	}
+
+	d.currencyPairM = m
+
+	return m, body, err
+}
+
+// NewCurrencyPair 永续合约不需要参数, 交割合约传入contractAlias: CURRENT_QUARTER, NEXT_QUARTER
+func (d *DApi) NewCurrencyPair(baseSym, quoteSym string, opts ...model.OptionParameter) (model.CurrencyPair, error) {
+	contractAlias := "PERPETUAL"
+	if len(opts) > 0 && opts[0].Key == "contractAlias" {
+		contractAlias = opts[0].Value
+	}
+
+	currencyPair := d.currencyPairM[baseSym+quoteSym+contractAlias]
+	if currencyPair.Symbol == "" {
+		return currencyPair, errors.New("not found currency pair")
+	}
+
+	return currencyPair, nil
+}
+
+// GetFundingRate premiumIndex: Rate为本期资金费率(lastFundingRate), Tm为本期收取时间, 同时返回标记价格和指数价格
+func (d *DApi) GetFundingRate(pair model.CurrencyPair, opts ...model.OptionParameter) (rate *model.FundingRate, responseBody []byte, err error) {
+	params := url.Values{}
+	params.Set("symbol", pair.Symbol)
+	util.MergeOptionParams(&params, opts...)
+
+	data, responseBody, err := d.DoNoAuthRequest(http.MethodGet, d.UriOpts.Endpoint+d.UriOpts.GetFundingRateUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	rate, err = d.UnmarshalOpts.GetFundingRateResponseUnmarshaler(data)
+	return rate, responseBody, err
+}
+
+// GetFundingRateHistory 历史资金费率, 按时间升序, limit最大1000
+func (d *DApi) GetFundingRateHistory(pair model.CurrencyPair, limit int, opts ...model.OptionParameter) (rates []model.FundingRate, responseBody []byte, err error) {
+	params := url.Values{}
+	params.Set("symbol", pair.Symbol)
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(min(limit, 1000)))
+	}
+	util.MergeOptionParams(&params, opts...)
+
+	data, responseBody, err := d.DoNoAuthRequest(http.MethodGet, d.UriOpts.Endpoint+d.UriOpts.GetFundingRateHistoryUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	rates, err = d.UnmarshalOpts.GetFundingRateHistoryResponseUnmarshaler(data)
+	return rates, responseBody, err
+}
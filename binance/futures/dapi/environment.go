@@ -0,0 +1,23 @@
+package dapi
+
+import (
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/options"
+)
+
+// WithEnvironment 测试网: testnet.binancefuture.com, 不支持的环境清空地址, 避免误连实盘
+func WithEnvironment(env options.Environment) options.UriOption {
+	return func(c *options.UriOptions) {
+		switch env {
+		case options.Env_Production:
+			c.Endpoint = "https://dapi.binance.com"
+			c.WsEndpoint = "wss://dstream.binance.com/ws"
+		case options.Env_Testnet:
+			c.Endpoint = "https://testnet.binancefuture.com"
+			c.WsEndpoint = "wss://dstream.binancefuture.com/ws"
+		default:
+			logger.Errorf("[binance dapi] unsupported environment: %s", env)
+			c.Endpoint, c.WsEndpoint = "", ""
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package dapi
+
+import (
+	"errors"
+	"fmt"
+	"github.com/buger/jsonparser"
+	"github.com/shadowors/goex/v2/model"
+	"github.com/spf13/cast"
+)
+
+// UnmarshalGetExchangeInfoResponse contractSize为每张合约面值(USD)
+func UnmarshalGetExchangeInfoResponse(data []byte) (map[string]model.CurrencyPair, error) {
+	var currencyPairMap = make(map[string]model.CurrencyPair, 40)
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var currencyPair model.CurrencyPair
+
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "symbol":
+				currencyPair.Symbol = valStr
+			case "baseAsset":
+				currencyPair.BaseSymbol = valStr
+			case "quoteAsset":
+				currencyPair.QuoteSymbol = valStr
+				currencyPair.ContractValCurrency = valStr
+			case "marginAsset":
+				currencyPair.SettlementCurrency = valStr
+			case "contractSize":
+				currencyPair.ContractVal = cast.ToFloat64(valStr)
+			case "contractType":
+				currencyPair.ContractAlias = valStr
+			case "pricePrecision":
+				currencyPair.PricePrecision = cast.ToInt(valStr)
+			case "quantityPrecision":
+				currencyPair.QtyPrecision = cast.ToInt(valStr)
+			case "deliveryDate":
+				currencyPair.ContractDeliveryDate = cast.ToInt64(valStr)
+			case "filters":
+				_, err = jsonparser.ArrayEach(val, func(filterData []byte, dataType jsonparser.ValueType, offset int, err error) {
+					filterType, _ := jsonparser.GetString(filterData, "filterType")
+					switch filterType {
+					case "PRICE_FILTER":
+						tickSize, _ := jsonparser.GetString(filterData, "tickSize")
+						currencyPair.TickSize = cast.ToFloat64(tickSize)
+					case "LOT_SIZE":
+						minQty, _ := jsonparser.GetString(filterData, "minQty")
+						maxQty, _ := jsonparser.GetString(filterData, "maxQty")
+						stepSize, _ := jsonparser.GetString(filterData, "stepSize")
+						currencyPair.MinQty = cast.ToFloat64(minQty)
+						currencyPair.MaxQty = cast.ToFloat64(maxQty)
+						currencyPair.LotSize = cast.ToFloat64(stepSize)
+					case "MARKET_LOT_SIZE":
+						maxQty, _ := jsonparser.GetString(filterData, "maxQty")
+						currencyPair.MarketQty = cast.ToFloat64(maxQty)
+					case "PERCENT_PRICE":
+						multiplierUp, _ := jsonparser.GetString(filterData, "multiplierUp")
+						multiplierDown, _ := jsonparser.GetString(filterData, "multiplierDown")
+						currencyPair.MultiplierUp = cast.ToFloat64(multiplierUp)
+						currencyPair.MultiplierDown = cast.ToFloat64(multiplierDown)
+					}
+				})
+			}
+			return err
+		})
+
+		k := fmt.Sprintf("%s%s%s", currencyPair.BaseSymbol, currencyPair.QuoteSymbol, currencyPair.ContractAlias)
+		currencyPairMap[k] = currencyPair
+	}, "symbols")
+
+	return currencyPairMap, err
+}
+
+func UnmarshalGetServerTimeResponse(data []byte) (int64, error) {
+	return jsonparser.GetInt(data, "serverTime")
+}
+
+// UnmarshalGetFundingRateResponse 指定symbol时也返回数组
+func UnmarshalGetFundingRateResponse(data []byte) (*model.FundingRate, error) {
+	var rates []model.FundingRate
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var rate model.FundingRate
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "symbol":
+				rate.Symbol = valStr
+			case "lastFundingRate":
+				rate.Rate = cast.ToFloat64(valStr)
+			case "nextFundingTime":
+				rate.Tm = cast.ToInt64(valStr)
+			case "markPrice":
+				rate.MarkPx = cast.ToFloat64(valStr)
+			case "indexPrice":
+				rate.IndexPx = cast.ToFloat64(valStr)
+			}
+			return nil
+		})
+		if err == nil {
+			rates = append(rates, rate)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("funding rate not found")
+	}
+	return &rates[0], nil
+}
+
+func UnmarshalGetFundingRateHistoryResponse(data []byte) ([]model.FundingRate, error) {
+	var rates []model.FundingRate
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var rate model.FundingRate
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "symbol":
+				rate.Symbol = valStr
+			case "fundingRate":
+				rate.Rate = cast.ToFloat64(valStr)
+			case "fundingTime":
+				rate.Tm = cast.ToInt64(valStr)
+			}
+			return nil
+		})
+		if err == nil {
+			rates = append(rates, rate)
+		}
+	})
+	return rates, err
+}
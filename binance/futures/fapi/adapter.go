@@ -0,0 +1,113 @@
+package fapi
+
+import (
+	"net/url"
+
+	"github.com/shadowors/goex/v2/binance/common"
+	"github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/util"
+)
+
+func adaptAlgoOrderTypeToString(ty model.OrderType) string {
+	switch ty {
+	case model.OrderType_StopMarket:
+		return "STOP_MARKET"
+	case model.OrderType_StopLimit:
+		return "STOP"
+	case model.OrderType_TakeProfitMarket:
+		return "TAKE_PROFIT_MARKET"
+	case model.OrderType_TakeProfitLimit:
+		return "TAKE_PROFIT"
+	case model.OrderType_TrailingStop:
+		return "TRAILING_STOP_MARKET"
+	}
+	return string(ty)
+}
+
+func adaptStringToOrderType(ty string) model.OrderType {
+	switch ty {
+	case "STOP_MARKET":
+		return model.OrderType_StopMarket
+	case "STOP":
+		return model.OrderType_StopLimit
+	case "TAKE_PROFIT_MARKET":
+		return model.OrderType_TakeProfitMarket
+	case "TAKE_PROFIT":
+		return model.OrderType_TakeProfitLimit
+	case "TRAILING_STOP_MARKET":
+		return model.OrderType_TrailingStop
+	}
+	return common.AdaptStringToOrderType(ty)
+}
+
+// isAlgoOrderType 条件单类型, 与普通订单共用订单接口
+func isAlgoOrderType(ty model.OrderType) bool {
+	switch ty {
+	case model.OrderType_StopMarket, model.OrderType_StopLimit,
+		model.OrderType_TakeProfitMarket, model.OrderType_TakeProfitLimit,
+		model.OrderType_TrailingStop:
+		return true
+	}
+	return false
+}
+
+// adaptOrderOptionParameters 转换类型化的下单参数
+func adaptOrderOptionParameters(params *url.Values) error {
+	opts, err := util.PopOrderOptions(params)
+	if err != nil {
+		return err
+	}
+
+	ty := params.Get("type")
+	limit := ty == "LIMIT" || ty == "STOP" || ty == "TAKE_PROFIT"
+
+	if opts.PostOnly {
+		if !limit {
+			return util.UnsupportedOption(model.BINANCE, "post only with order type %s", ty)
+		}
+		params.Set("timeInForce", string(model.TimeInForce_GTX))
+	} else if opts.TimeInForce != "" {
+		if !limit {
+			return util.UnsupportedOption(model.BINANCE, "time in force %s with order type %s", opts.TimeInForce, ty)
+		}
+		params.Set("timeInForce", string(opts.TimeInForce))
+	}
+
+	if opts.ReduceOnly {
+		params.Set("reduceOnly", "true")
+	}
+
+	if opts.ClosePosition { //只用于STOP_MARKET和TAKE_PROFIT_MARKET, 不能传数量
+		if ty != "STOP_MARKET" && ty != "TAKE_PROFIT_MARKET" {
+			return util.UnsupportedOption(model.BINANCE, "close position with order type %s", ty)
+		}
+		params.Set("closePosition", "true")
+		params.Del("quantity")
+	}
+
+	if opts.STPMode != "" {
+		params.Set("selfTradePreventionMode", adaptSTPModeToString(opts.STPMode))
+	}
+
+	if opts.PriceMatch != "" { //不能同时传价格
+		if !limit {
+			return util.UnsupportedOption(model.BINANCE, "price match with order type %s", ty)
+		}
+		params.Set("priceMatch", string(opts.PriceMatch))
+		params.Del("price")
+	}
+
+	return nil
+}
+
+func adaptSTPModeToString(mode model.STPMode) string {
+	switch mode {
+	case model.STPMode_CancelTaker:
+		return "EXPIRE_TAKER"
+	case model.STPMode_CancelMaker:
+		return "EXPIRE_MAKER"
+	case model.STPMode_CancelBoth:
+		return "EXPIRE_BOTH"
+	}
+	return string(mode)
+}
@@ -0,0 +1,25 @@
+package fapi
+
+import (
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/options"
+)
+
+// WithEnvironment 测试网: testnet.binancefuture.com, 不支持的环境清空地址, 避免误连实盘
+func WithEnvironment(env options.Environment) options.UriOption {
+	return func(c *options.UriOptions) {
+		switch env {
+		case options.Env_Production:
+			c.Endpoint = "https://fapi.binance.com"
+			c.WsEndpoint = "wss://fstream.binance.com/ws"
+			c.WsPrivateEndpoint = "wss://ws-fapi.binance.com/ws-fapi/v1"
+		case options.Env_Testnet:
+			c.Endpoint = "https://testnet.binancefuture.com"
+			c.WsEndpoint = "wss://fstream.binancefuture.com/ws"
+			c.WsPrivateEndpoint = "wss://testnet.binancefuture.com/ws-fapi/v1"
+		default:
+			logger.Errorf("[binance fapi] unsupported environment: %s", env)
+			c.Endpoint, c.WsEndpoint, c.WsPrivateEndpoint = "", "", ""
+		}
+	}
+}
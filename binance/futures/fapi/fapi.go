@@ -15,30 +15,70 @@ type FApi struct {
 func NewFApi() *FApi {
 	f := &FApi{
 		UriOpts: options.UriOptions{
-			Endpoint:            "https://fapi.binance.com",
-			KlineUri:            "/fapi/v1/klines",
-			TickerUri:           "/fapi/v1/ticker/24hr",
-			DepthUri:            "/fapi/v1/depth",
-			NewOrderUri:         "/fapi/v1/order",
-			GetOrderUri:         "/fapi/v1/order",
-			GetHistoryOrdersUri: "/fapi/v1/allOrders",
-			GetPendingOrdersUri: "/fapi/v1/openOrders",
-			CancelOrderUri:      "/fapi/v1/order",
-			GetAccountUri:       "/fapi/v2/balance",
-			GetPositionsUri:     "/fapi/v2/positionRisk",
-			GetExchangeInfoUri:  "/fapi/v1/exchangeInfo",
+			Endpoint:                 "https://fapi.binance.com",
+			WsEndpoint:               "wss://fstream.binance.com/ws",
+			WsPrivateEndpoint:        "wss://ws-fapi.binance.com/ws-fapi/v1",
+			KlineUri:                 "/fapi/v1/klines",
+			TickerUri:                "/fapi/v1/ticker/24hr",
+			DepthUri:                 "/fapi/v1/depth",
+			NewOrderUri:              "/fapi/v1/order",
+			GetOrderUri:              "/fapi/v1/order",
+			GetHistoryOrdersUri:      "/fapi/v1/allOrders",
+			GetPendingOrdersUri:      "/fapi/v1/openOrders",
+			CancelOrderUri:           "/fapi/v1/order",
+			GetAccountUri:            "/fapi/v2/balance",
+			GetPositionsUri:          "/fapi/v2/positionRisk",
+			GetExchangeInfoUri:       "/fapi/v1/exchangeInfo",
+			NewOrdersUri:             "/fapi/v1/batchOrders",
+			CancelOrdersUri:          "/fapi/v1/batchOrders",
+			AmendOrderUri:            "/fapi/v1/order",
+			CancelAllOrdersUri:       "/fapi/v1/allOpenOrders",
+			CancelAllAfterUri:        "/fapi/v1/countdownCancelAll",
+			SetLeverageUri:           "/fapi/v1/leverage",
+			SetMarginModeUri:         "/fapi/v1/marginType",
+			SetPositionModeUri:       "/fapi/v1/positionSide/dual",
+			AdjustMarginUri:          "/fapi/v1/positionMargin",
+			NewAlgoOrderUri:          "/fapi/v1/order",
+			GetAlgoOrderUri:          "/fapi/v1/order",
+			GetPendingAlgoOrdersUri:  "/fapi/v1/openOrders",
+			CancelAlgoOrderUri:       "/fapi/v1/order",
+			GetFillsUri:              "/fapi/v1/userTrades",
+			GetTradesUri:             "/fapi/v1/trades",
+			GetTickersUri:            "/fapi/v1/ticker/24hr",
+			GetServerTimeUri:         "/fapi/v1/time",
+			GetBookTickersUri:        "/fapi/v1/ticker/bookTicker",
+			GetHistoryTradesUri:      "/fapi/v1/aggTrades",
+			GetFundingRateUri:        "/fapi/v1/premiumIndex",
+			GetFundingRateHistoryUri: "/fapi/v1/fundingRate",
 		},
 		UnmarshalOpts: options.UnmarshalerOptions{
-			GetExchangeInfoResponseUnmarshaler:  UnmarshalGetExchangeInfoResponse,
-			DepthUnmarshaler:                    UnmarshalDepthResponse,
-			KlineUnmarshaler:                    UnmarshalKlinesResponse,
-			GetAccountResponseUnmarshaler:       UnmarshalGetAccountResponse,
-			CreateOrderResponseUnmarshaler:      UnmarshalCreateOrderResponse,
-			CancelOrderResponseUnmarshaler:      UnmarshalCancelOrderResponse,
-			GetOrderInfoResponseUnmarshaler:     UnmarshalGetOrderInfoResponse,
-			GetPendingOrdersResponseUnmarshaler: UnmarshalGetPendingOrdersResponse,
-			GetHistoryOrdersResponseUnmarshaler: UnmarshalGetHistoryOrdersResponse,
-			GetPositionsResponseUnmarshaler:     UnmarshalGetPositionsResponse,
+			GetExchangeInfoResponseUnmarshaler:       UnmarshalGetExchangeInfoResponse,
+			DepthUnmarshaler:                         UnmarshalDepthResponse,
+			KlineUnmarshaler:                         UnmarshalKlinesResponse,
+			GetAccountResponseUnmarshaler:            UnmarshalGetAccountResponse,
+			GetFuturesAccountResponseUnmarshaler:     UnmarshalGetFuturesAccountResponse,
+			CreateOrderResponseUnmarshaler:           UnmarshalCreateOrderResponse,
+			CancelOrderResponseUnmarshaler:           UnmarshalCancelOrderResponse,
+			GetOrderInfoResponseUnmarshaler:          UnmarshalGetOrderInfoResponse,
+			GetPendingOrdersResponseUnmarshaler:      UnmarshalGetPendingOrdersResponse,
+			GetHistoryOrdersResponseUnmarshaler:      UnmarshalGetHistoryOrdersResponse,
+			GetPositionsResponseUnmarshaler:          UnmarshalGetPositionsResponse,
+			CreateOrdersResponseUnmarshaler:          UnmarshalBatchOrdersResponse,
+			CancelOrdersResponseUnmarshaler:          UnmarshalBatchOrdersResponse,
+			AmendOrderResponseUnmarshaler:            UnmarshalGetOrderInfoResponse,
+			CancelAllOrdersResponseUnmarshaler:       UnmarshalCancelAllOrdersResponse,
+			CreateAlgoOrderResponseUnmarshaler:       UnmarshalCreateOrderResponse,
+			GetAlgoOrderInfoResponseUnmarshaler:      UnmarshalGetOrderInfoResponse,
+			GetPendingAlgoOrdersResponseUnmarshaler:  UnmarshalGetPendingOrdersResponse,
+			CancelAlgoOrderResponseUnmarshaler:       UnmarshalCancelOrderResponse,
+			GetFillsResponseUnmarshaler:              UnmarshalGetFillsResponse,
+			GetTradesResponseUnmarshaler:             UnmarshalGetTradesResponse,
+			GetTickersResponseUnmarshaler:            UnmarshalGetTickersResponse,
+			GetServerTimeResponseUnmarshaler:         UnmarshalGetServerTimeResponse,
+			GetBookTickersResponseUnmarshaler:        UnmarshalGetBookTickersResponse,
+			GetHistoryTradesResponseUnmarshaler:      UnmarshalGetAggTradesResponse,
+			GetFundingRateResponseUnmarshaler:        UnmarshalGetFundingRateResponse,
+			GetFundingRateHistoryResponseUnmarshaler: UnmarshalGetFundingRateHistoryResponse,
 		},
 	}
 
@@ -1,15 +1,26 @@
 package fapi
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/shadowors/goex/v2/binance/common"
 	"github.com/shadowors/goex/v2/httpcli"
 	"github.com/shadowors/goex/v2/logger"
 	. "github.com/shadowors/goex/v2/model"
 	"github.com/shadowors/goex/v2/options"
 	"github.com/shadowors/goex/v2/util"
+	"github.com/shadowors/goex/v2/validator"
+	"math"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	maxBatchOrders       = 5  //批量下单单次最大订单数
+	maxBatchCancelOrders = 10 //批量撤单单次最大订单数
 )
 
 type Prv struct {
@@ -17,6 +28,11 @@ type Prv struct {
 	apiOpts options.ApiOptions
 }
 
+// NewWsApi 连接WebSocket API并以session.logon认证, 需要Ed25519私钥
+func (p *Prv) NewWsApi() (*common.WsApi, []byte, error) {
+	return common.DialWsApi(p.UriOpts.WsPrivateEndpoint, p.apiOpts)
+}
+
 func (p *Prv) GetAccount(currency string) (map[string]Account, []byte, error) {
 	param := &url.Values{}
 	responseBody, err := p.DoAuthRequest(http.MethodGet, p.UriOpts.Endpoint+p.UriOpts.GetAccountUri, param, nil)
@@ -28,29 +44,17 @@ func (p *Prv) GetAccount(currency string) (map[string]Account, []byte, error) {
 }
 
 func (p *Prv) CreateOrder(pair CurrencyPair, qty, price float64, side OrderSide, orderTy OrderType, opt ...OptionParameter) (order *Order, responseBody []byte, err error) {
-	if orderTy == OrderType_Limit && qty*price < 5.0 { //币安规则
-		return nil, nil, errors.New("MIN NOTIONAL must >= 5.0 USDT")
+	err = validator.Chain(p.apiOpts.OrderValidators).Validate(&OrderRequest{
+		Pair: pair, Qty: qty, Price: price, Side: side, OrderTy: orderTy, Opts: opt})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	var param = url.Values{}
-	param.Set("symbol", pair.Symbol)
-	param.Set("price", util.FloatToString(price, pair.PricePrecision))
-	param.Set("quantity", util.FloatToString(qty, pair.QtyPrecision))
-	param.Set("type", common.AdaptOrderTypeToString(orderTy))
-	param.Set("side", common.AdaptOrderSideToString(side))
-	param.Set("timeInForce", "GTC")
-	param.Set("newOrderRespType", "ACK")
-
-	switch side {
-	case Futures_OpenSell, Futures_CloseSell:
-		param.Set("positionSide", "SHORT")
-	case Futures_OpenBuy, Futures_CloseBuy:
-		param.Set("positionSide", "LONG")
+	param, err := orderParams(pair, qty, price, side, orderTy, opt...)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	util.MergeOptionParams(&param, opt...)           //合并参数
-	common.AdaptOrderClientIDOptionParameter(&param) //client id
-
 	responseBody, err = p.DoAuthRequest(http.MethodPost, p.UriOpts.Endpoint+p.UriOpts.NewOrderUri, &param, nil)
 	if err != nil {
 		return nil, responseBody, err
@@ -68,6 +72,168 @@ func (p *Prv) CreateOrder(pair CurrencyPair, qty, price float64, side OrderSide,
 	return ord, responseBody, err
 }
 
+// CreateOrders 批量下单, 每次最多5个订单
+func (p *Prv) CreateOrders(reqs []OrderRequest, opt ...OptionParameter) ([]OrderResult, []byte, error) {
+	var (
+		results      = make([]OrderResult, len(reqs))
+		pending      []int
+		responseBody []byte
+		errs         []error
+	)
+
+	for i := range reqs {
+		err := validator.Chain(p.apiOpts.OrderValidators).Validate(&reqs[i])
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	for start := 0; start < len(pending); start += maxBatchOrders {
+		chunk := pending[start:min(start+maxBatchOrders, len(pending))]
+
+		batchOrders := make([]map[string]string, 0, len(chunk))
+		sent := make([]int, 0, len(chunk))
+		for _, i := range chunk {
+			req := reqs[i]
+			params, err := orderParams(req.Pair, req.Qty, req.Price, req.Side, req.OrderTy, append(req.Opts, opt...)...)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			item := make(map[string]string, len(params))
+			for k := range params {
+				item[k] = params.Get(k)
+			}
+			batchOrders = append(batchOrders, item)
+			sent = append(sent, i)
+		}
+		if chunk = sent; len(chunk) == 0 {
+			continue
+		}
+		batchOrdersData, _ := json.Marshal(batchOrders)
+
+		param := &url.Values{}
+		param.Set("batchOrders", string(batchOrdersData))
+
+		data, err := p.DoAuthRequest(http.MethodPost, p.UriOpts.Endpoint+p.UriOpts.NewOrdersUri, param, nil)
+		responseBody = data
+		var rets []OrderResult
+		if err == nil {
+			rets, err = p.UnmarshalOpts.CreateOrdersResponseUnmarshaler(data)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			for _, i := range chunk {
+				results[i].Err = err
+			}
+			continue
+		}
+
+		for j, i := range chunk {
+			if j >= len(rets) {
+				results[i].Err = errors.New("missing order result")
+				continue
+			}
+			results[i] = rets[j]
+			if rets[j].Order != nil {
+				req := reqs[i]
+				rets[j].Order.Pair = req.Pair
+				rets[j].Order.Price = req.Price
+				rets[j].Order.Qty = req.Qty
+				rets[j].Order.Side = req.Side
+				rets[j].Order.OrderTy = req.OrderTy
+			}
+		}
+	}
+
+	return results, responseBody, errors.Join(errs...)
+}
+
+// CancelOrders 批量撤单, 每次最多10个订单
+func (p *Prv) CancelOrders(pair CurrencyPair, ids []string, opt ...OptionParameter) ([]OrderResult, []byte, error) {
+	var (
+		results      = make([]OrderResult, len(ids))
+		responseBody []byte
+		errs         []error
+	)
+
+	for start := 0; start < len(ids); start += maxBatchCancelOrders {
+		end := min(start+maxBatchCancelOrders, len(ids))
+
+		param := &url.Values{}
+		param.Set("symbol", pair.Symbol)
+		param.Set("orderIdList", "["+strings.Join(ids[start:end], ",")+"]")
+		util.MergeOptionParams(param, opt...)
+
+		data, err := p.DoAuthRequest(http.MethodDelete, p.UriOpts.Endpoint+p.UriOpts.CancelOrdersUri, param, nil)
+		responseBody = data
+		var rets []OrderResult
+		if err == nil {
+			rets, err = p.UnmarshalOpts.CancelOrdersResponseUnmarshaler(data)
+		}
+
+		for i := start; i < end; i++ {
+			results[i].Id = ids[i]
+			if err != nil {
+				results[i].Err = err
+			} else if i-start < len(rets) {
+				results[i].Err = rets[i-start].Err
+			} else {
+				results[i].Err = errors.New("missing order result")
+			}
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return results, responseBody, errors.Join(errs...)
+}
+
+// AmendOrder 原生改单(PUT /fapi/v1/order), 接口要求side/quantity/price,
+// opt中未传入side时先查询原订单
+func (p *Prv) AmendOrder(pair CurrencyPair, id string, newQty, newPrice float64, opt ...OptionParameter) (*AmendResult, []byte, error) {
+	param := &url.Values{}
+	param.Set("symbol", pair.Symbol)
+	param.Set("orderId", id)
+	util.MergeOptionParams(param, opt...)
+
+	if param.Get("side") == "" || newQty <= 0 || newPrice <= 0 {
+		orig, data, err := p.GetOrderInfo(pair, id)
+		if err != nil {
+			return nil, data, err
+		}
+		if param.Get("side") == "" {
+			param.Set("side", common.AdaptOrderSideToString(orig.Side))
+		}
+		if newQty <= 0 {
+			newQty = orig.Qty
+		}
+		if newPrice <= 0 {
+			newPrice = orig.Price
+		}
+	}
+
+	param.Set("quantity", util.FloatToString(newQty, pair.QtyPrecision))
+	param.Set("price", util.FloatToString(newPrice, pair.PricePrecision))
+
+	data, err := p.DoAuthRequest(http.MethodPut, p.UriOpts.Endpoint+p.UriOpts.AmendOrderUri, param, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	ord, err := p.UnmarshalOpts.AmendOrderResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+	ord.Pair = pair
+
+	return &AmendResult{Mode: AmendMode_Native, OrigId: id, Order: ord}, data, nil
+}
+
 func (p *Prv) GetOrderInfo(pair CurrencyPair, id string, opt ...OptionParameter) (order *Order, responseBody []byte, err error) {
 	param := &url.Values{}
 	param.Set("symbol", pair.Symbol)
@@ -154,13 +320,209 @@ func (p *Prv) CancelOrder(pair CurrencyPair, id string, opt ...OptionParameter)
 	return data, err
 }
 
+func (p *Prv) CancelAllOrders(pair CurrencyPair, opt ...OptionParameter) ([]byte, error) {
+	param := &url.Values{}
+	param.Set("symbol", pair.Symbol)
+	util.MergeOptionParams(param, opt...)
+
+	data, err := p.DoAuthRequest(http.MethodDelete, p.UriOpts.Endpoint+p.UriOpts.CancelAllOrdersUri, param, nil)
+	if err != nil {
+		return data, err
+	}
+
+	return data, p.UnmarshalOpts.CancelAllOrdersResponseUnmarshaler(data)
+}
+
+// CancelAllAfter 按交易对倒计时撤单, timeout为0时取消倒计时
+func (p *Prv) CancelAllAfter(pair CurrencyPair, timeout time.Duration, opt ...OptionParameter) ([]byte, error) {
+	param := &url.Values{}
+	param.Set("symbol", pair.Symbol)
+	param.Set("countdownTime", fmt.Sprint(timeout.Milliseconds()))
+	util.MergeOptionParams(param, opt...)
+
+	return p.DoAuthRequest(http.MethodPost, p.UriOpts.Endpoint+p.UriOpts.CancelAllAfterUri, param, nil)
+}
+
+// CreateAlgoOrder 条件单, 不支持OCO. 触发价格类型(workingType)等通过opt传入
+func (p *Prv) CreateAlgoOrder(req AlgoOrderRequest, opt ...OptionParameter) (*Order, []byte, error) {
+	if !isAlgoOrderType(req.OrderTy) {
+		return nil, nil, fmt.Errorf("binance futures not support algo order type: %s", req.OrderTy)
+	}
+
+	param := url.Values{}
+	param.Set("symbol", req.Pair.Symbol)
+	param.Set("quantity", util.FloatToString(req.Qty, req.Pair.QtyPrecision))
+	param.Set("type", adaptAlgoOrderTypeToString(req.OrderTy))
+	param.Set("side", common.AdaptOrderSideToString(req.Side))
+	param.Set("newOrderRespType", "ACK")
+
+	switch req.Side {
+	case Futures_OpenSell, Futures_CloseSell:
+		param.Set("positionSide", "SHORT")
+	case Futures_OpenBuy, Futures_CloseBuy:
+		param.Set("positionSide", "LONG")
+	}
+
+	switch req.OrderTy {
+	case OrderType_TrailingStop:
+		param.Set("callbackRate", util.FloatToString(req.CallbackRate*100, 1))
+		if req.TriggerPx > 0 {
+			param.Set("activationPrice", util.FloatToString(req.TriggerPx, req.Pair.PricePrecision))
+		}
+	case OrderType_StopLimit, OrderType_TakeProfitLimit:
+		param.Set("stopPrice", util.FloatToString(req.TriggerPx, req.Pair.PricePrecision))
+		param.Set("price", util.FloatToString(req.Price, req.Pair.PricePrecision))
+		param.Set("timeInForce", "GTC")
+	default:
+		param.Set("stopPrice", util.FloatToString(req.TriggerPx, req.Pair.PricePrecision))
+	}
+
+	util.MergeOptionParams(&param, append(req.Opts, opt...)...)
+	common.AdaptOrderClientIDOptionParameter(&param)
+	if err := adaptOrderOptionParameters(&param); err != nil {
+		return nil, nil, err
+	}
+
+	responseBody, err := p.DoAuthRequest(http.MethodPost, p.UriOpts.Endpoint+p.UriOpts.NewAlgoOrderUri, &param, nil)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	ord, err := p.UnmarshalOpts.CreateAlgoOrderResponseUnmarshaler(responseBody)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	ord.Pair = req.Pair
+	ord.Qty = req.Qty
+	ord.Price = req.Price
+	ord.Side = req.Side
+	ord.OrderTy = req.OrderTy
+	ord.TriggerPx = req.TriggerPx
+	ord.CallbackRate = req.CallbackRate
+
+	return ord, responseBody, nil
+}
+
+func (p *Prv) GetAlgoOrderInfo(pair CurrencyPair, id string, opt ...OptionParameter) (*Order, []byte, error) {
+	param := &url.Values{}
+	param.Set("symbol", pair.Symbol)
+	param.Set("orderId", id)
+	util.MergeOptionParams(param, opt...)
+
+	data, err := p.DoAuthRequest(http.MethodGet, p.UriOpts.Endpoint+p.UriOpts.GetAlgoOrderUri, param, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	ord, err := p.UnmarshalOpts.GetAlgoOrderInfoResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+	ord.Pair = pair
+
+	return ord, data, nil
+}
+
+// GetPendingAlgoOrders 从当前挂单中筛选出条件单
+func (p *Prv) GetPendingAlgoOrders(pair CurrencyPair, opt ...OptionParameter) ([]Order, []byte, error) {
+	param := &url.Values{}
+	param.Set("symbol", pair.Symbol)
+	util.MergeOptionParams(param, opt...)
+
+	data, err := p.DoAuthRequest(http.MethodGet, p.UriOpts.Endpoint+p.UriOpts.GetPendingAlgoOrdersUri, param, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	orders, err := p.UnmarshalOpts.GetPendingAlgoOrdersResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	algoOrders := make([]Order, 0, len(orders))
+	for _, ord := range orders {
+		if !isAlgoOrderType(ord.OrderTy) {
+			continue
+		}
+		ord.Pair = pair
+		algoOrders = append(algoOrders, ord)
+	}
+
+	return algoOrders, data, nil
+}
+
+func (p *Prv) CancelAlgoOrder(pair CurrencyPair, id string, opt ...OptionParameter) ([]byte, error) {
+	param := &url.Values{}
+	param.Set("symbol", pair.Symbol)
+	param.Set("orderId", id)
+	util.MergeOptionParams(param, opt...)
+
+	data, err := p.DoAuthRequest(http.MethodDelete, p.UriOpts.Endpoint+p.UriOpts.CancelAlgoOrderUri, param, nil)
+	if err != nil {
+		return data, err
+	}
+
+	return data, p.UnmarshalOpts.CancelAlgoOrderResponseUnmarshaler(data)
+}
+
+// GetFills 返回按时间升序, limit最大1000
+func (p *Prv) GetFills(pair CurrencyPair, since int64, limit int, opt ...OptionParameter) ([]Trade, []byte, error) {
+	param := &url.Values{}
+	param.Set("symbol", pair.Symbol)
+	if since > 0 {
+		param.Set("startTime", fmt.Sprint(since))
+	}
+	if limit > 0 {
+		param.Set("limit", fmt.Sprint(min(limit, 1000)))
+	}
+	util.MergeOptionParams(param, opt...)
+
+	data, err := p.DoAuthRequest(http.MethodGet, p.UriOpts.Endpoint+p.UriOpts.GetFillsUri, param, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	fills, err := p.UnmarshalOpts.GetFillsResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	for i := range fills {
+		fills[i].Pair = pair
+	}
+
+	return fills, data, nil
+}
+
 func (p *Prv) GetFuturesAccount(currency string) (acc map[string]FuturesAccount, responseBody []byte, err error) {
-	panic("not implement")
+	param := &url.Values{}
+	responseBody, err = p.DoAuthRequest(http.MethodGet, p.UriOpts.Endpoint+p.UriOpts.GetAccountUri, param, nil)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	acc, err = p.UnmarshalOpts.GetFuturesAccountResponseUnmarshaler(responseBody)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	if currency != "" {
+		for coin := range acc {
+			if coin != currency {
+				delete(acc, coin)
+			}
+		}
+	}
+
+	return acc, responseBody, nil
 }
 
 func (p *Prv) GetPositions(pair CurrencyPair, opts ...OptionParameter) (positions []FuturesPosition, responseBody []byte, err error) {
 	param := &url.Values{}
-	param.Set("symbol", pair.Symbol)
+	if pair.Symbol != "" { //为空时返回所有持仓
+		param.Set("symbol", pair.Symbol)
+	}
 
 	util.MergeOptionParams(param, opts...)
 
@@ -174,19 +536,94 @@ func (p *Prv) GetPositions(pair CurrencyPair, opts ...OptionParameter) (position
 		return nil, data, err
 	}
 
-	for i, _ := range pos {
-		pos[i].Pair = pair
+	if pair.Symbol != "" {
+		for i, _ := range pos {
+			pos[i].Pair = pair
+		}
 	}
 
 	return pos, data, nil
 }
 
+// GetLeverage 从持仓风险接口获取杠杆倍数, marginMode无效
+func (p *Prv) GetLeverage(pair CurrencyPair, marginMode MarginMode, opts ...OptionParameter) (float64, []byte, error) {
+	positions, responseBody, err := p.GetPositions(pair, opts...)
+	if err != nil {
+		return 0, responseBody, err
+	}
+	if len(positions) == 0 {
+		return 0, responseBody, fmt.Errorf("no position risk of %s", pair.Symbol)
+	}
+	return positions[0].Lever, responseBody, nil
+}
+
+// SetLeverage 杠杆倍数为整数, marginMode无效(由SetMarginMode切换)
+func (p *Prv) SetLeverage(pair CurrencyPair, lever float64, marginMode MarginMode, opts ...OptionParameter) ([]byte, error) {
+	param := &url.Values{}
+	param.Set("symbol", pair.Symbol)
+	param.Set("leverage", fmt.Sprint(int(lever)))
+	util.MergeOptionParams(param, opts...)
+	return p.DoAuthRequest(http.MethodPost, p.UriOpts.Endpoint+p.UriOpts.SetLeverageUri, param, nil)
+}
+
+func (p *Prv) SetMarginMode(pair CurrencyPair, mode MarginMode, opts ...OptionParameter) ([]byte, error) {
+	param := &url.Values{}
+	param.Set("symbol", pair.Symbol)
+	if mode == MarginMode_Isolated {
+		param.Set("marginType", "ISOLATED")
+	} else {
+		param.Set("marginType", "CROSSED")
+	}
+	util.MergeOptionParams(param, opts...)
+
+	data, err := p.DoAuthRequest(http.MethodPost, p.UriOpts.Endpoint+p.UriOpts.SetMarginModeUri, param, nil)
+	if err != nil && strings.Contains(string(data), "-4046") { //No need to change margin type.
+		return data, nil
+	}
+	return data, err
+}
+
+func (p *Prv) SetPositionMode(mode PositionMode, opts ...OptionParameter) ([]byte, error) {
+	param := &url.Values{}
+	param.Set("dualSidePosition", fmt.Sprint(mode == PositionMode_Hedge))
+	util.MergeOptionParams(param, opts...)
+
+	data, err := p.DoAuthRequest(http.MethodPost, p.UriOpts.Endpoint+p.UriOpts.SetPositionModeUri, param, nil)
+	if err != nil && strings.Contains(string(data), "-4059") { //No need to change position side.
+		return data, nil
+	}
+	return data, err
+}
+
+func (p *Prv) AdjustMargin(pair CurrencyPair, posSide OrderSide, amount float64, opts ...OptionParameter) ([]byte, error) {
+	param := &url.Values{}
+	param.Set("symbol", pair.Symbol)
+	switch posSide {
+	case Futures_OpenBuy, Futures_CloseBuy:
+		param.Set("positionSide", "LONG")
+	case Futures_OpenSell, Futures_CloseSell:
+		param.Set("positionSide", "SHORT")
+	default:
+		param.Set("positionSide", "BOTH")
+	}
+	param.Set("amount", util.FloatToString(math.Abs(amount), 8))
+	if amount > 0 {
+		param.Set("type", "1")
+	} else {
+		param.Set("type", "2")
+	}
+	util.MergeOptionParams(param, opts...)
+	return p.DoAuthRequest(http.MethodPost, p.UriOpts.Endpoint+p.UriOpts.AdjustMarginUri, param, nil)
+}
+
 func (p *Prv) DoAuthRequest(method, reqUrl string, params *url.Values, header map[string]string) ([]byte, error) {
 	if header == nil {
 		header = make(map[string]string, 2)
 	}
 	header["X-MBX-APIKEY"] = p.apiOpts.Key
-	common.SignParams(params, p.apiOpts.Secret)
+	if err := common.SignParams(params, p.apiOpts); err != nil {
+		return nil, err
+	}
 	//if http.MethodGet == method {
 	reqUrl += "?" + params.Encode()
 	//}
@@ -195,9 +632,36 @@ func (p *Prv) DoAuthRequest(method, reqUrl string, params *url.Values, header ma
 	return respBody, err
 }
 
+// orderParams 限价单默认GTC, 有效方式等通过类型化参数设置, 见adaptOrderOptionParameters
+func orderParams(pair CurrencyPair, qty, price float64, side OrderSide, orderTy OrderType, opt ...OptionParameter) (url.Values, error) {
+	var param = url.Values{}
+	param.Set("symbol", pair.Symbol)
+	param.Set("quantity", util.FloatToString(qty, pair.QtyPrecision))
+	param.Set("type", common.AdaptOrderTypeToString(orderTy))
+	param.Set("side", common.AdaptOrderSideToString(side))
+	param.Set("newOrderRespType", "ACK")
+	if orderTy != OrderType_Market {
+		param.Set("price", util.FloatToString(price, pair.PricePrecision))
+		param.Set("timeInForce", "GTC")
+	}
+
+	switch side {
+	case Futures_OpenSell, Futures_CloseSell:
+		param.Set("positionSide", "SHORT")
+	case Futures_OpenBuy, Futures_CloseBuy:
+		param.Set("positionSide", "LONG")
+	}
+
+	util.MergeOptionParams(&param, opt...)           //合并参数
+	common.AdaptOrderClientIDOptionParameter(&param) //client id
+
+	return param, adaptOrderOptionParameters(&param)
+}
+
 func NewPrvApi(fapi *FApi, opts ...options.ApiOption) *Prv {
 	var prv = new(Prv)
 	prv.FApi = fapi
+	prv.apiOpts.OrderValidators = validator.Defaults()
 	for _, opt := range opts {
 		opt(&prv.apiOpts)
 	}
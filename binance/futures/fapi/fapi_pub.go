@@ -30,6 +30,17 @@ func (f *FApi) GetName() string {
 	return "binance.com"
 }
 
+// GetServerTime 服务器时间(毫秒)
+func (f *FApi) GetServerTime() (int64, []byte, error) {
+	data, body, err := f.DoNoAuthRequest(http.MethodGet, f.UriOpts.Endpoint+f.UriOpts.GetServerTimeUri, &url.Values{})
+	if err != nil {
+		return 0, body, err
+	}
+
+	ts, err := f.UnmarshalOpts.GetServerTimeResponseUnmarshaler(data)
+	return ts, body, err
+}
+
 func (f *FApi) GetExchangeInfo() (map[string]model.CurrencyPair, []byte, error) {
 	data, body, err := f.DoNoAuthRequest(http.MethodGet, f.UriOpts.Endpoint+f.UriOpts.GetExchangeInfoUri, &url.Values{})
 	if err != nil {
@@ -91,6 +102,44 @@ func (f *FApi) GetTicker(pair model.CurrencyPair, opt ...model.OptionParameter)
 	panic("implement me")
 }
 
+// GetTickers 合并24小时行情与最优挂单, instType无效
+func (f *FApi) GetTickers(instType string, opt ...model.OptionParameter) (tickers map[string]model.Ticker, responseBody []byte, err error) {
+	params := url.Values{}
+	util.MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := f.DoNoAuthRequest(http.MethodGet, f.UriOpts.Endpoint+f.UriOpts.GetTickersUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	tickers, err = f.UnmarshalOpts.GetTickersResponseUnmarshaler(data)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	data, responseBody, err = f.DoNoAuthRequest(http.MethodGet, f.UriOpts.Endpoint+f.UriOpts.GetBookTickersUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	bookTickers, err := f.UnmarshalOpts.GetBookTickersResponseUnmarshaler(data)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	for sym, bookTk := range bookTickers {
+		tk, ok := tickers[sym]
+		if !ok {
+			continue
+		}
+		tk.Buy, tk.BuyQty = bookTk.Buy, bookTk.BuyQty
+		tk.Sell, tk.SellQty = bookTk.Sell, bookTk.SellQty
+		tickers[sym] = tk
+	}
+
+	return tickers, responseBody, nil
+}
+
 func (f *FApi) GetKline(pair model.CurrencyPair, period model.KlinePeriod, opt ...model.OptionParameter) (klines []model.Kline, responseBody []byte, err error) {
 	var param = url.Values{}
 	param.Set("symbol", pair.Symbol)
@@ -112,3 +161,82 @@ func (f *FApi) GetKline(pair model.CurrencyPair, period model.KlinePeriod, opt .
 
 	return klines, responseBody, err
 }
+
+// GetTrades 最近成交, 按时间升序, limit最大1000
+func (f *FApi) GetTrades(pair model.CurrencyPair, limit int, opt ...model.OptionParameter) (trades []model.Trade, responseBody []byte, err error) {
+	params := url.Values{}
+	params.Set("symbol", pair.Symbol)
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(min(limit, 1000)))
+	}
+
+	util.MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := f.DoNoAuthRequest(http.MethodGet, f.UriOpts.Endpoint+f.UriOpts.GetTradesUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	trades, err = f.UnmarshalOpts.GetTradesResponseUnmarshaler(data)
+
+	for i := range trades {
+		trades[i].Pair = pair
+	}
+
+	return trades, responseBody, err
+}
+
+// GetHistoryTrades 归集成交, 返回ID小于afterId的limit条记录, 按时间升序
+func (f *FApi) GetHistoryTrades(pair model.CurrencyPair, afterId string, limit int, opt ...model.OptionParameter) (trades []model.Trade, responseBody []byte, err error) {
+	params := url.Values{}
+	params.Set("symbol", pair.Symbol)
+	common.AdaptAggTradesPageParams(&params, afterId, limit)
+
+	util.MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := f.DoNoAuthRequest(http.MethodGet, f.UriOpts.Endpoint+f.UriOpts.GetHistoryTradesUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	trades, err = f.UnmarshalOpts.GetHistoryTradesResponseUnmarshaler(data)
+
+	for i := range trades {
+		trades[i].Pair = pair
+	}
+
+	return trades, responseBody, err
+}
+
+// GetFundingRate premiumIndex: Rate为本期资金费率(lastFundingRate), Tm为本期收取时间, 同时返回标记价格和指数价格
+func (f *FApi) GetFundingRate(pair model.CurrencyPair, opts ...model.OptionParameter) (rate *model.FundingRate, responseBody []byte, err error) {
+	params := url.Values{}
+	params.Set("symbol", pair.Symbol)
+	util.MergeOptionParams(&params, opts...)
+
+	data, responseBody, err := f.DoNoAuthRequest(http.MethodGet, f.UriOpts.Endpoint+f.UriOpts.GetFundingRateUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	rate, err = f.UnmarshalOpts.GetFundingRateResponseUnmarshaler(data)
+	return rate, responseBody, err
+}
+
+// GetFundingRateHistory 历史资金费率, 按时间升序, limit最大1000
+func (f *FApi) GetFundingRateHistory(pair model.CurrencyPair, limit int, opts ...model.OptionParameter) (rates []model.FundingRate, responseBody []byte, err error) {
+	params := url.Values{}
+	params.Set("symbol", pair.Symbol)
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(min(limit, 1000)))
+	}
+	util.MergeOptionParams(&params, opts...)
+
+	data, responseBody, err := f.DoNoAuthRequest(http.MethodGet, f.UriOpts.Endpoint+f.UriOpts.GetFundingRateHistoryUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	rates, err = f.UnmarshalOpts.GetFundingRateHistoryResponseUnmarshaler(data)
+	return rates, responseBody, err
+}
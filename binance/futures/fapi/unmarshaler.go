@@ -21,8 +21,7 @@ func UnmarshalGetExchangeInfoResponse(data []byte) (map[string]model.CurrencyPai
 			currencyPair model.CurrencyPair
 		)
 
-		currencyPair.ContractVal = 1
-		currencyPair.ContractValCurrency = model.USDT
+		currencyPair.ContractVal = 1 //USDⓈ-M下单数量单位为币
 
 		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
 			valStr := string(val)
@@ -33,6 +32,8 @@ func UnmarshalGetExchangeInfoResponse(data []byte) (map[string]model.CurrencyPai
 				currencyPair.BaseSymbol = valStr
 			case "quoteAsset":
 				currencyPair.QuoteSymbol = valStr
+			case "marginAsset":
+				currencyPair.SettlementCurrency = valStr
 			case "contractType":
 				currencyPair.ContractAlias = valStr
 			case "pricePrecision":
@@ -46,34 +47,36 @@ func UnmarshalGetExchangeInfoResponse(data []byte) (map[string]model.CurrencyPai
 			case "filters":
 				_, err = jsonparser.ArrayEach(val, func(filterData []byte, dataType jsonparser.ValueType, offset int, err error) {
 					filterType, _ := jsonparser.GetString(filterData, "filterType")
-					if filterType == "LOT_SIZE" {
-						var (
-							minQty []byte
-							maxQty []byte
-						)
-
-						minQty, _, _, err = jsonparser.Get(filterData, "minQty")
-						maxQty, _, _, err = jsonparser.Get(filterData, "maxQty")
-
-						currencyPair.MinQty = cast.ToFloat64(string(minQty))
-						currencyPair.MaxQty = cast.ToFloat64(string(maxQty))
-					}
-
-					if filterType == "MARKET_LOT_SIZE" {
-
+					switch filterType {
+					case "PRICE_FILTER":
+						tickSize, _ := jsonparser.GetString(filterData, "tickSize")
+						currencyPair.TickSize = cast.ToFloat64(tickSize)
+					case "LOT_SIZE":
+						minQty, _ := jsonparser.GetString(filterData, "minQty")
+						maxQty, _ := jsonparser.GetString(filterData, "maxQty")
+						stepSize, _ := jsonparser.GetString(filterData, "stepSize")
+						currencyPair.MinQty = cast.ToFloat64(minQty)
+						currencyPair.MaxQty = cast.ToFloat64(maxQty)
+						currencyPair.LotSize = cast.ToFloat64(stepSize)
+					case "MARKET_LOT_SIZE":
+						maxQty, _ := jsonparser.GetString(filterData, "maxQty")
+						currencyPair.MarketQty = cast.ToFloat64(maxQty)
+					case "MIN_NOTIONAL":
+						notional, _ := jsonparser.GetString(filterData, "notional")
+						currencyPair.MinNotional = cast.ToFloat64(notional)
+					case "PERCENT_PRICE":
+						multiplierUp, _ := jsonparser.GetString(filterData, "multiplierUp")
+						multiplierDown, _ := jsonparser.GetString(filterData, "multiplierDown")
+						currencyPair.MultiplierUp = cast.ToFloat64(multiplierUp)
+						currencyPair.MultiplierDown = cast.ToFloat64(multiplierDown)
 					}
-
-					//if filterType == "MIN_NOTIONAL" {
-					//	currencyPair.MinQty, err = jsonparser.GetFloat(filterData, "notional")
-					//	if err != nil {
-					//		logger.Errorf("[UnmarshalGetExchangeInfoResponse] get notional error: %s", err.Error())
-					//	}
-					//}
 				})
 			}
 			return err
 		})
 
+		currencyPair.ContractValCurrency = currencyPair.BaseSymbol
+
 		k := fmt.Sprintf("%s%s%s", currencyPair.BaseSymbol, currencyPair.QuoteSymbol, currencyPair.ContractAlias)
 		currencyPairMap[k] = currencyPair
 
@@ -82,6 +85,108 @@ func UnmarshalGetExchangeInfoResponse(data []byte) (map[string]model.CurrencyPai
 	return currencyPairMap, err
 }
 
+// UnmarshalGetFundingRateResponse premiumIndex, 只返回本期费率, 没有预测费率
+func UnmarshalGetFundingRateResponse(data []byte) (*model.FundingRate, error) {
+	var rate model.FundingRate
+	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		valStr := string(value)
+		switch string(key) {
+		case "symbol":
+			rate.Symbol = valStr
+		case "lastFundingRate":
+			rate.Rate = cast.ToFloat64(valStr)
+		case "nextFundingTime":
+			rate.Tm = cast.ToInt64(valStr)
+		case "markPrice":
+			rate.MarkPx = cast.ToFloat64(valStr)
+		case "indexPrice":
+			rate.IndexPx = cast.ToFloat64(valStr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func UnmarshalGetFundingRateHistoryResponse(data []byte) ([]model.FundingRate, error) {
+	var rates []model.FundingRate
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var rate model.FundingRate
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "symbol":
+				rate.Symbol = valStr
+			case "fundingRate":
+				rate.Rate = cast.ToFloat64(valStr)
+			case "fundingTime":
+				rate.Tm = cast.ToInt64(valStr)
+			case "markPrice":
+				rate.MarkPx = cast.ToFloat64(valStr)
+			}
+			return nil
+		})
+		if err == nil {
+			rates = append(rates, rate)
+		}
+	})
+	return rates, err
+}
+
+func UnmarshalGetServerTimeResponse(data []byte) (int64, error) {
+	return jsonparser.GetInt(data, "serverTime")
+}
+
+// UnmarshalGetTickersResponse 24小时行情不包含买一卖一, 需要合并bookTicker
+func UnmarshalGetTickersResponse(data []byte) (map[string]model.Ticker, error) {
+	var tickers = make(map[string]model.Ticker, 256)
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var tk model.Ticker
+		err = unmarshalTicker(value, &tk)
+		tickers[tk.Pair.Symbol] = tk
+	})
+	return tickers, err
+}
+
+func UnmarshalGetBookTickersResponse(data []byte) (map[string]model.Ticker, error) {
+	return UnmarshalGetTickersResponse(data)
+}
+
+func unmarshalTicker(data []byte, tk *model.Ticker) error {
+	return jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		valStr := string(value)
+		switch string(key) {
+		case "symbol":
+			tk.Pair.Symbol = valStr
+		case "lastPrice":
+			tk.Last = cast.ToFloat64(valStr)
+		case "bidPrice":
+			tk.Buy = cast.ToFloat64(valStr)
+		case "bidQty":
+			tk.BuyQty = cast.ToFloat64(valStr)
+		case "askPrice":
+			tk.Sell = cast.ToFloat64(valStr)
+		case "askQty":
+			tk.SellQty = cast.ToFloat64(valStr)
+		case "volume":
+			tk.Vol = cast.ToFloat64(valStr)
+		case "quoteVolume":
+			tk.QuoteVol = cast.ToFloat64(valStr)
+		case "highPrice":
+			tk.High = cast.ToFloat64(valStr)
+		case "lowPrice":
+			tk.Low = cast.ToFloat64(valStr)
+		case "priceChangePercent":
+			tk.Percent = cast.ToFloat64(valStr)
+		case "closeTime", "time":
+			tk.Timestamp = cast.ToInt64(valStr)
+		}
+		return nil
+	})
+}
+
 func UnmarshalDepthResponse(data []byte) (*model.Depth, error) {
 	var (
 		dep model.Depth
@@ -183,6 +288,34 @@ func UnmarshalGetAccountResponse(data []byte) (map[string]model.Account, error)
 	return accounts, err
 }
 
+func UnmarshalGetFuturesAccountResponse(data []byte) (map[string]model.FuturesAccount, error) {
+	var accounts = make(map[string]model.FuturesAccount, 4)
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			acc     model.FuturesAccount
+			balance float64
+		)
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "asset":
+				acc.Coin = valStr
+			case "balance":
+				balance = cast.ToFloat64(valStr)
+			case "availableBalance":
+				acc.AvailEq = cast.ToFloat64(valStr)
+			case "crossUnPnl":
+				acc.Upl = cast.ToFloat64(valStr)
+			}
+			return nil
+		})
+		acc.Eq = balance + acc.Upl
+		acc.FrozenBal = acc.Eq - acc.AvailEq
+		accounts[acc.Coin] = acc
+	})
+	return accounts, err
+}
+
 func UnmarshalCreateOrderResponse(data []byte) (*model.Order, error) {
 	var order model.Order
 	order.Status = model.OrderStatus_Pending
@@ -253,8 +386,18 @@ func UnmarshalOrderResponse(data []byte) (ord model.Order, err error) {
 			ord.Price = cast.ToFloat64(valStr)
 		case "origQty":
 			ord.Qty = cast.ToFloat64(valStr)
-		case "executeQty":
+		case "executedQty":
 			ord.ExecutedQty = cast.ToFloat64(valStr)
+		case "avgPrice":
+			ord.PriceAvg = cast.ToFloat64(valStr)
+		case "stopPrice":
+			if ord.TriggerPx == 0 {
+				ord.TriggerPx = cast.ToFloat64(valStr)
+			}
+		case "activatePrice":
+			ord.TriggerPx = cast.ToFloat64(valStr)
+		case "priceRate": //回调比例, 单位: %
+			ord.CallbackRate = cast.ToFloat64(valStr) / 100
 		case "time":
 			ord.CreatedAt = cast.ToInt64(valStr)
 		case "updateTime":
@@ -266,7 +409,7 @@ func UnmarshalOrderResponse(data []byte) (ord model.Order, err error) {
 		case "positionSide":
 			positionSide = valStr
 		case "type":
-			ord.OrderTy = common.AdaptStringToOrderType(valStr)
+			ord.OrderTy = adaptStringToOrderType(valStr)
 		}
 		return nil
 	})
@@ -280,6 +423,105 @@ func UnmarshalOrderResponse(data []byte) (ord model.Order, err error) {
 	return
 }
 
+func UnmarshalGetTradesResponse(data []byte) ([]model.Trade, error) {
+	var trades []model.Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			trade      model.Trade
+			buyerMaker bool
+		)
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "id":
+				trade.Id = valStr
+			case "price":
+				trade.Price = cast.ToFloat64(valStr)
+			case "qty":
+				trade.Qty = cast.ToFloat64(valStr)
+			case "time":
+				trade.Ts = cast.ToInt64(valStr)
+			case "isBuyerMaker":
+				buyerMaker = valStr == "true"
+			}
+			return nil
+		})
+		trade.Side = common.AdaptBuyerMakerToSide(buyerMaker)
+		trades = append(trades, trade)
+	})
+	return trades, err
+}
+
+func UnmarshalGetAggTradesResponse(data []byte) ([]model.Trade, error) {
+	var trades []model.Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			trade      model.Trade
+			buyerMaker bool
+		)
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "a":
+				trade.Id = valStr
+			case "p":
+				trade.Price = cast.ToFloat64(valStr)
+			case "q":
+				trade.Qty = cast.ToFloat64(valStr)
+			case "T":
+				trade.Ts = cast.ToInt64(valStr)
+			case "m":
+				buyerMaker = valStr == "true"
+			}
+			return nil
+		})
+		trade.Side = common.AdaptBuyerMakerToSide(buyerMaker)
+		trades = append(trades, trade)
+	})
+	return trades, err
+}
+
+func UnmarshalGetFillsResponse(data []byte) ([]model.Trade, error) {
+	var fills []model.Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			fill               model.Trade
+			side, positionSide string
+		)
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "symbol":
+				fill.Pair.Symbol = valStr
+			case "id":
+				fill.Id = valStr
+			case "orderId":
+				fill.OrderId = valStr
+			case "price":
+				fill.Price = cast.ToFloat64(valStr)
+			case "qty":
+				fill.Qty = cast.ToFloat64(valStr)
+			case "commission":
+				fill.Fee = cast.ToFloat64(valStr)
+			case "commissionAsset":
+				fill.FeeCcy = valStr
+			case "maker":
+				fill.IsMaker = valStr == "true"
+			case "time":
+				fill.Ts = cast.ToInt64(valStr)
+			case "side":
+				side = valStr
+			case "positionSide":
+				positionSide = valStr
+			}
+			return nil
+		})
+		fill.Side = common.AdaptStringToFuturesOrderSide(side, positionSide)
+		fills = append(fills, fill)
+	})
+	return fills, err
+}
+
 func UnmarshalCancelOrderResponse(data []byte) error {
 	_, err := jsonparser.GetString(data, "code")
 	if err == nil {
@@ -288,6 +530,36 @@ func UnmarshalCancelOrderResponse(data []byte) error {
 	return nil
 }
 
+// UnmarshalBatchOrdersResponse 批量下单/撤单返回, 失败的订单为{"code":xx,"msg":"xx"}
+func UnmarshalBatchOrdersResponse(data []byte) ([]model.OrderResult, error) {
+	var results []model.OrderResult
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var ret model.OrderResult
+		if code, err := jsonparser.GetInt(value, "code"); err == nil && code != 0 {
+			msg, _ := jsonparser.GetString(value, "msg")
+			ret.Err = fmt.Errorf("%d: %s", code, msg)
+			results = append(results, ret)
+			return
+		}
+		ret.Order, ret.Err = UnmarshalCreateOrderResponse(value)
+		if ret.Order != nil {
+			ret.Id = ret.Order.Id
+			ret.CId = ret.Order.CId
+		}
+		results = append(results, ret)
+	})
+	return results, err
+}
+
+// UnmarshalCancelAllOrdersResponse 成功返回: {"code":200,"msg":"The operation of cancel all open order is done."}
+func UnmarshalCancelAllOrdersResponse(data []byte) error {
+	code, err := jsonparser.GetInt(data, "code")
+	if err == nil && code != 200 {
+		return errors.New(string(data))
+	}
+	return nil
+}
+
 func UnmarshalGetPositionsResponse(data []byte) ([]model.FuturesPosition, error) {
 	var positions []model.FuturesPosition
 	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
@@ -312,6 +584,10 @@ func UnmarshalGetPositionsResponse(data []byte) ([]model.FuturesPosition, error)
 				pos.Upl = cast.ToFloat64(valStr)
 			case "positionSide":
 				posSide = valStr
+			case "symbol":
+				pos.Pair.Symbol = valStr
+			case "isolatedMargin":
+				pos.Margin = cast.ToFloat64(valStr)
 			}
 			return nil
 		})
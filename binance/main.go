@@ -3,6 +3,7 @@ package binance
 import (
 	"github.com/shadowors/goex/v2/binance/futures/fapi"
 	"github.com/shadowors/goex/v2/binance/spot"
+	"github.com/shadowors/goex/v2/options"
 )
 
 type Binance struct {
@@ -16,3 +17,10 @@ func New() *Binance {
 		Swap: fapi.NewFApi(),
 	}
 }
+
+// WithEnvironment 切换实盘/测试网, 同时作用于Spot,Swap
+func (b *Binance) WithEnvironment(env options.Environment) *Binance {
+	b.Spot.WithUriOption(spot.WithEnvironment(env))
+	b.Swap.WithUriOption(fapi.WithEnvironment(env))
+	return b
+}
@@ -1,8 +1,12 @@
 package spot
 
 import (
+	"fmt"
 	"github.com/shadowors/goex/v2/logger"
 	"github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/util"
+	"net/url"
+	"strings"
 )
 
 func adaptKlinePeriod(period model.KlinePeriod) string {
@@ -49,6 +53,47 @@ func adaptOrderType(ty model.OrderType) string {
 	return string(ty)
 }
 
+// adaptOrderOptionParameters 转换类型化的下单参数, 只做maker使用LIMIT_MAKER类型
+func adaptOrderOptionParameters(params *url.Values) error {
+	opts, err := util.PopOrderOptions(params)
+	if err != nil {
+		return err
+	}
+
+	ty := params.Get("type")
+	if opts.PostOnly {
+		if ty != "LIMIT" {
+			return util.UnsupportedOption(model.BINANCE, "post only with order type %s", ty)
+		}
+		params.Set("type", "LIMIT_MAKER")
+		params.Del("timeInForce")
+	} else if opts.TimeInForce != "" {
+		if ty != "LIMIT" {
+			return util.UnsupportedOption(model.BINANCE, "time in force %s with order type %s", opts.TimeInForce, ty)
+		}
+		params.Set("timeInForce", string(opts.TimeInForce))
+	}
+
+	if opts.ReduceOnly || opts.ClosePosition {
+		return util.UnsupportedOption(model.BINANCE, "reduce only or close position in spot order")
+	}
+
+	if opts.PriceMatch != "" {
+		return util.UnsupportedOption(model.BINANCE, "price match in spot order")
+	}
+
+	switch opts.STPMode {
+	case model.STPMode_CancelTaker:
+		params.Set("selfTradePreventionMode", "EXPIRE_TAKER")
+	case model.STPMode_CancelMaker:
+		params.Set("selfTradePreventionMode", "EXPIRE_MAKER")
+	case model.STPMode_CancelBoth:
+		params.Set("selfTradePreventionMode", "EXPIRE_BOTH")
+	}
+
+	return nil
+}
+
 func adaptOrderStatus(st string) model.OrderStatus {
 	switch st {
 	case "NEW":
@@ -63,6 +108,19 @@ func adaptOrderStatus(st string) model.OrderStatus {
 	return model.OrderStatus(-1)
 }
 
+// adaptOrderListStatus OCO订单组状态, 全部结束(成交或撤销)为ALL_DONE
+func adaptOrderListStatus(st string) model.OrderStatus {
+	switch st {
+	case "EXECUTING":
+		return model.OrderStatus_Pending
+	case "ALL_DONE":
+		return model.OrderStatus_Finished
+	case "REJECT":
+		return model.OrderStatus_Rejected
+	}
+	return model.OrderStatus(-1)
+}
+
 func adaptOrderOrigSide(side string) model.OrderSide {
 	switch side {
 	case "BUY":
@@ -100,3 +158,67 @@ func adaptOrderOrigStatus(st string) model.OrderStatus {
 		return model.OrderStatus(-1)
 	}
 }
+
+// adaptTransferType 万向划转类型为FROM_TO, 如MAIN_UMFUTURE
+func adaptTransferType(from, to model.AccountType) (string, error) {
+	fromSym, err := adaptAccountType(from)
+	if err != nil {
+		return "", err
+	}
+	toSym, err := adaptAccountType(to)
+	if err != nil {
+		return "", err
+	}
+	if fromSym == toSym {
+		return "", fmt.Errorf("%s and %s are the same account in binance", from, to)
+	}
+	return fromSym + "_" + toSym, nil
+}
+
+// adaptAccountType 币安没有统一交易账户, trading同现货账户
+func adaptAccountType(ty model.AccountType) (string, error) {
+	switch ty {
+	case model.AccountType_Funding:
+		return "FUNDING", nil
+	case model.AccountType_Trading, model.AccountType_Spot:
+		return "MAIN", nil
+	case model.AccountType_UsdtFutures:
+		return "UMFUTURE", nil
+	case model.AccountType_CoinFutures:
+		return "CMFUTURE", nil
+	case model.AccountType_Margin:
+		return "MARGIN", nil
+	}
+	return "", fmt.Errorf("%s unsupported account type: %s", model.BINANCE, ty)
+}
+
+func adaptSymToAccountType(sym string) model.AccountType {
+	switch sym {
+	case "FUNDING":
+		return model.AccountType_Funding
+	case "MAIN":
+		return model.AccountType_Spot
+	case "UMFUTURE":
+		return model.AccountType_UsdtFutures
+	case "CMFUTURE":
+		return model.AccountType_CoinFutures
+	case "MARGIN":
+		return model.AccountType_Margin
+	}
+	return model.AccountType(sym)
+}
+
+func adaptSymToTransferType(ty string) (from, to model.AccountType) {
+	fromSym, toSym, _ := strings.Cut(ty, "_")
+	return adaptSymToAccountType(fromSym), adaptSymToAccountType(toSym)
+}
+
+func adaptTransferStatus(st string) model.TransferStatus {
+	switch st {
+	case "CONFIRMED":
+		return model.TransferStatus_Success
+	case "FAILED":
+		return model.TransferStatus_Failed
+	}
+	return model.TransferStatus_Pending
+}
@@ -0,0 +1,25 @@
+package spot
+
+import (
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/options"
+)
+
+// WithEnvironment 测试网: testnet.binance.vision, 不支持的环境清空地址, 避免误连实盘
+func WithEnvironment(env options.Environment) options.UriOption {
+	return func(c *options.UriOptions) {
+		switch env {
+		case options.Env_Production:
+			c.Endpoint = "https://api.binance.com"
+			c.WsEndpoint = "wss://stream.binance.com:9443/ws"
+			c.WsPrivateEndpoint = "wss://ws-api.binance.com:443/ws-api/v3"
+		case options.Env_Testnet:
+			c.Endpoint = "https://testnet.binance.vision"
+			c.WsEndpoint = "wss://stream.testnet.binance.vision/ws"
+			c.WsPrivateEndpoint = "wss://ws-api.testnet.binance.vision/ws-api/v3"
+		default:
+			logger.Errorf("[binance spot] unsupported environment: %s", env)
+			c.Endpoint, c.WsEndpoint, c.WsPrivateEndpoint = "", "", ""
+		}
+	}
+}
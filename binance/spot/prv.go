@@ -8,6 +8,7 @@ import (
 	. "github.com/shadowors/goex/v2/model"
 	"github.com/shadowors/goex/v2/options"
 	. "github.com/shadowors/goex/v2/util"
+	"github.com/shadowors/goex/v2/validator"
 	"net/http"
 	"net/url"
 )
@@ -19,29 +20,66 @@ type PrvApi struct {
 
 func NewPrvApi(apiOpts ...options.ApiOption) *PrvApi {
 	s := new(PrvApi)
+	s.apiOpts.OrderValidators = validator.Defaults()
 	for _, opt := range apiOpts {
 		opt(&s.apiOpts)
 	}
 	return s
 }
 
+// NewWsApi 连接WebSocket API并以session.logon认证, 需要Ed25519私钥
+func (s *PrvApi) NewWsApi() (*common.WsApi, []byte, error) {
+	return common.DialWsApi(s.UriOpts.WsPrivateEndpoint, s.apiOpts)
+}
+
 func (s *PrvApi) GetAccount(coin string) (map[string]Account, []byte, error) {
-	//TODO implement me
-	panic("implement me")
+	var params = url.Values{}
+	params.Set("omitZeroBalances", "true")
+	data, err := s.DoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetAccountUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	accounts, err := s.UnmarshalerOpts.GetAccountResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	if coin != "" {
+		for c := range accounts {
+			if c != coin {
+				delete(accounts, c)
+			}
+		}
+	}
+
+	return accounts, data, nil
 }
 
 func (s *PrvApi) CreateOrder(pair CurrencyPair, qty, price float64, side OrderSide, orderTy OrderType, opt ...OptionParameter) (*Order, []byte, error) {
+	err := validator.Chain(s.apiOpts.OrderValidators).Validate(&OrderRequest{
+		Pair: pair, Qty: qty, Price: price, Side: side, OrderTy: orderTy, Opts: opt})
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var params = url.Values{}
 	params.Set("symbol", pair.Symbol)
 	params.Set("side", adaptOrderSide(side))
 	params.Set("type", adaptOrderType(orderTy))
-	params.Set("timeInForce", "GTC")
 	params.Set("quantity", FloatToString(qty, pair.QtyPrecision))
-	params.Set("price", FloatToString(price, pair.PricePrecision))
 	params.Set("newOrderRespType", "ACK")
+	if orderTy != OrderType_Market {
+		params.Set("timeInForce", "GTC")
+		params.Set("price", FloatToString(price, pair.PricePrecision))
+	}
 
 	MergeOptionParams(&params, opt...)
 	common.AdaptOrderClientIDOptionParameter(&params)
+	if err = adaptOrderOptionParameters(&params); err != nil {
+		return nil, nil, err
+	}
 
 	data, err := s.DoAuthRequest(http.MethodPost,
 		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.NewOrderUri), &params, nil)
@@ -54,19 +92,89 @@ func (s *PrvApi) CreateOrder(pair CurrencyPair, qty, price float64, side OrderSi
 		return nil, data, err
 	}
 
-	order := new(Order)
-	ord.Pair = order.Pair
-	ord.Price = order.Price
-	ord.Qty = order.Qty
+	ord.Pair = pair
+	ord.Price = price
+	ord.Qty = qty
 	ord.Status = OrderStatus_Pending
-	ord.Side = order.Side
-	ord.OrderTy = order.OrderTy
+	ord.Side = side
+	ord.OrderTy = orderTy
 
 	return ord, data, nil
 }
 
+// CreateOrders 现货没有批量下单接口, 逐个调用CreateOrder
+func (s *PrvApi) CreateOrders(reqs []OrderRequest, opt ...OptionParameter) ([]OrderResult, []byte, error) {
+	var (
+		results      = make([]OrderResult, len(reqs))
+		responseBody []byte
+	)
+
+	for i, req := range reqs {
+		ord, data, err := s.CreateOrder(req.Pair, req.Qty, req.Price, req.Side, req.OrderTy, append(req.Opts, opt...)...)
+		if data != nil {
+			responseBody = data
+		}
+		results[i].Err = err
+		if ord != nil {
+			results[i].Id = ord.Id
+			results[i].CId = ord.CId
+			results[i].Order = ord
+		}
+	}
+
+	return results, responseBody, nil
+}
+
+// CancelOrders 逐个调用CancelOrder
+func (s *PrvApi) CancelOrders(pair CurrencyPair, ids []string, opt ...OptionParameter) ([]OrderResult, []byte, error) {
+	var (
+		results      = make([]OrderResult, len(ids))
+		responseBody []byte
+	)
+
+	for i, id := range ids {
+		data, err := s.CancelOrder(pair, id, opt...)
+		if data != nil {
+			responseBody = data
+		}
+		results[i].Id = id
+		results[i].Err = err
+	}
+
+	return results, responseBody, nil
+}
+
 func (s *PrvApi) GetOrderInfo(pair CurrencyPair, id string, opt ...OptionParameter) (*Order, []byte, error) {
-	panic("")
+	var params = url.Values{}
+	params.Set("symbol", pair.Symbol)
+	if id != "" {
+		params.Set("orderId", id)
+	}
+	MergeOptionParams(&params, opt...)
+	common.AdaptOrderClientIDOptionParameter(&params)
+	if cid := params.Get("newClientOrderId"); cid != "" { //查询接口参数为origClientOrderId
+		params.Set("origClientOrderId", cid)
+		params.Del("newClientOrderId")
+	}
+
+	data, err := s.DoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetOrderUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	ord, err := s.UnmarshalerOpts.GetOrderInfoResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+	ord.Pair = pair
+
+	return ord, data, nil
+}
+
+// AmendOrder 现货不支持原生改单, 撤单后重新下单
+func (s *PrvApi) AmendOrder(pair CurrencyPair, id string, newQty, newPrice float64, opt ...OptionParameter) (*AmendResult, []byte, error) {
+	return CancelReplaceOrder(s, pair, id, newQty, newPrice, opt...)
 }
 
 func (s *PrvApi) GetPendingOrders(pair CurrencyPair, opt ...OptionParameter) ([]Order, []byte, error) {
@@ -102,12 +210,237 @@ func (s *PrvApi) CancelOrder(pair CurrencyPair, id string, opt ...OptionParamete
 	return data, s.UnmarshalerOpts.CancelOrderResponseUnmarshaler(data)
 }
 
+func (s *PrvApi) CancelAllOrders(pair CurrencyPair, opt ...OptionParameter) ([]byte, error) {
+	var params = url.Values{}
+	params.Set("symbol", pair.Symbol)
+	MergeOptionParams(&params, opt...)
+	return s.DoAuthRequest(http.MethodDelete, fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.CancelAllOrdersUri), &params, nil)
+}
+
+// CreateAlgoOrder 只支持OCO, 止盈(TAKE_PROFIT)和止损(STOP_LOSS)两个条件单二选一, 委托价格为0时为市价
+func (s *PrvApi) CreateAlgoOrder(req AlgoOrderRequest, opt ...OptionParameter) (*Order, []byte, error) {
+	if req.OrderTy != OrderType_OCO {
+		return nil, nil, fmt.Errorf("binance spot not support algo order type: %s", req.OrderTy)
+	}
+
+	var params = url.Values{}
+	params.Set("symbol", req.Pair.Symbol)
+	params.Set("side", adaptOrderSide(req.Side))
+	params.Set("quantity", FloatToString(req.Qty, req.Pair.QtyPrecision))
+	params.Set("newOrderRespType", "ACK")
+
+	//卖出时止盈在上方, 买入时止损在上方
+	tp, sl := "above", "below"
+	if req.Side == Spot_Buy {
+		tp, sl = sl, tp
+	}
+	setLeg := func(leg, ty string, triggerPx, px float64) {
+		params.Set(leg+"StopPrice", FloatToString(triggerPx, req.Pair.PricePrecision))
+		if px > 0 {
+			params.Set(leg+"Type", ty+"_LIMIT")
+			params.Set(leg+"Price", FloatToString(px, req.Pair.PricePrecision))
+			params.Set(leg+"TimeInForce", "GTC")
+		} else {
+			params.Set(leg+"Type", ty)
+		}
+	}
+	setLeg(tp, "TAKE_PROFIT", req.TpTriggerPx, req.TpPx)
+	setLeg(sl, "STOP_LOSS", req.SlTriggerPx, req.SlPx)
+
+	MergeOptionParams(&params, append(req.Opts, opt...)...)
+	if cid := params.Get(Order_Client_ID__Opt_Key); cid != "" {
+		params.Set("listClientOrderId", cid)
+		params.Del(Order_Client_ID__Opt_Key)
+	}
+	if err := adaptOrderOptionParameters(&params); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := s.DoAuthRequest(http.MethodPost,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.NewAlgoOrderUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	ord, err := s.UnmarshalerOpts.CreateAlgoOrderResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	ord.Pair = req.Pair
+	ord.Qty = req.Qty
+	ord.Side = req.Side
+	ord.TpTriggerPx = req.TpTriggerPx
+	ord.TpPx = req.TpPx
+	ord.SlTriggerPx = req.SlTriggerPx
+	ord.SlPx = req.SlPx
+
+	return ord, data, nil
+}
+
+func (s *PrvApi) GetAlgoOrderInfo(pair CurrencyPair, id string, opt ...OptionParameter) (*Order, []byte, error) {
+	var params = url.Values{}
+	params.Set("orderListId", id)
+	MergeOptionParams(&params, opt...)
+
+	data, err := s.DoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetAlgoOrderUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	ord, err := s.UnmarshalerOpts.GetAlgoOrderInfoResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+	ord.Pair = pair
+
+	return ord, data, nil
+}
+
+// GetPendingAlgoOrders 交易所返回所有交易对的OCO订单, 这里按pair过滤
+func (s *PrvApi) GetPendingAlgoOrders(pair CurrencyPair, opt ...OptionParameter) ([]Order, []byte, error) {
+	var params = url.Values{}
+	MergeOptionParams(&params, opt...)
+
+	data, err := s.DoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetPendingAlgoOrdersUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	orders, err := s.UnmarshalerOpts.GetPendingAlgoOrdersResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	algoOrders := make([]Order, 0, len(orders))
+	for _, ord := range orders {
+		if pair.Symbol != "" && ord.Pair.Symbol != pair.Symbol {
+			continue
+		}
+		if pair.Symbol != "" {
+			ord.Pair = pair
+		}
+		algoOrders = append(algoOrders, ord)
+	}
+
+	return algoOrders, data, nil
+}
+
+func (s *PrvApi) CancelAlgoOrder(pair CurrencyPair, id string, opt ...OptionParameter) ([]byte, error) {
+	var params = url.Values{}
+	params.Set("symbol", pair.Symbol)
+	params.Set("orderListId", id)
+	MergeOptionParams(&params, opt...)
+
+	data, err := s.DoAuthRequest(http.MethodDelete,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.CancelAlgoOrderUri), &params, nil)
+	if err != nil {
+		return data, err
+	}
+	return data, s.UnmarshalerOpts.CancelAlgoOrderResponseUnmarshaler(data)
+}
+
+// GetFills 返回按时间升序, limit最大1000
+func (s *PrvApi) GetFills(pair CurrencyPair, since int64, limit int, opt ...OptionParameter) ([]Trade, []byte, error) {
+	var params = url.Values{}
+	params.Set("symbol", pair.Symbol)
+	if since > 0 {
+		params.Set("startTime", fmt.Sprint(since))
+	}
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(min(limit, 1000)))
+	}
+	MergeOptionParams(&params, opt...)
+
+	data, err := s.DoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetFillsUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	fills, err := s.UnmarshalerOpts.GetFillsResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	for i := range fills {
+		fills[i].Pair = pair
+	}
+
+	return fills, data, nil
+}
+
+// Transfer 万向划转, 需要api key开启万向划转权限
+func (s *PrvApi) Transfer(ccy string, amount float64, from, to AccountType, opt ...OptionParameter) (*Transfer, []byte, error) {
+	ty, err := adaptTransferType(from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var params = url.Values{}
+	params.Set("type", ty)
+	params.Set("asset", ccy)
+	params.Set("amount", FloatToString(amount, 8))
+	MergeOptionParams(&params, opt...)
+
+	data, err := s.DoAuthRequest(http.MethodPost,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.TransferUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	transfer, err := s.UnmarshalerOpts.TransferResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	transfer.Ccy, transfer.Amount, transfer.From, transfer.To = ccy, amount, from, to
+
+	return transfer, data, nil
+}
+
+// GetTransfer 在from/to对应类型的划转历史中查找, 默认查询最近7天, 更早的记录通过opt传入startTime
+func (s *PrvApi) GetTransfer(id string, from, to AccountType, opt ...OptionParameter) (*Transfer, []byte, error) {
+	ty, err := adaptTransferType(from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var params = url.Values{}
+	params.Set("type", ty)
+	params.Set("size", "100")
+	MergeOptionParams(&params, opt...)
+
+	data, err := s.DoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetTransferUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	transfers, err := s.UnmarshalerOpts.GetTransfersResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	for _, transfer := range transfers {
+		if transfer.Id == id {
+			return &transfer, data, nil
+		}
+	}
+
+	return nil, data, fmt.Errorf("transfer %s not found", id)
+}
+
 func (s *PrvApi) DoAuthRequest(method, reqUrl string, params *url.Values, header map[string]string) ([]byte, error) {
 	if header == nil {
 		header = make(map[string]string, 2)
 	}
 	header["X-MBX-APIKEY"] = s.apiOpts.Key
-	common.SignParams(params, s.apiOpts.Secret)
+	if err := common.SignParams(params, s.apiOpts); err != nil {
+		return nil, err
+	}
 	//if http.MethodGet == method {
 	reqUrl += "?" + params.Encode()
 	//}
@@ -3,6 +3,7 @@ package spot
 import (
 	"errors"
 	"fmt"
+	"github.com/shadowors/goex/v2/binance/common"
 	. "github.com/shadowors/goex/v2/httpcli"
 	"github.com/shadowors/goex/v2/logger"
 	. "github.com/shadowors/goex/v2/model"
@@ -15,6 +16,18 @@ func (s *Spot) GetName() string {
 	return "binance.com"
 }
 
+// GetServerTime 服务器时间(毫秒)
+func (s *Spot) GetServerTime() (int64, []byte, error) {
+	respBody, err := s.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetServerTimeUri), &url.Values{}, nil)
+	if err != nil {
+		return 0, respBody, err
+	}
+
+	ts, err := s.UnmarshalerOpts.GetServerTimeResponseUnmarshaler(respBody)
+	return ts, respBody, err
+}
+
 func (s *Spot) GetDepth(pair CurrencyPair, size int, opts ...OptionParameter) (*Depth, []byte, error) {
 	params := url.Values{}
 	params.Set("symbol", pair.Symbol)
@@ -60,6 +73,21 @@ func (s *Spot) GetTicker(pair CurrencyPair, opt ...OptionParameter) (*Ticker, []
 	return tk, data, err
 }
 
+// GetTickers 返回全部交易对的24小时行情, instType无效
+func (s *Spot) GetTickers(instType string, opts ...OptionParameter) (map[string]Ticker, []byte, error) {
+	params := url.Values{}
+	MergeOptionParams(&params, opts...)
+
+	respBody, err := s.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetTickersUri), &params, nil)
+	if err != nil {
+		return nil, respBody, err
+	}
+
+	tickers, err := s.UnmarshalerOpts.GetTickersResponseUnmarshaler(respBody)
+	return tickers, respBody, err
+}
+
 func (s *Spot) GetKline(pair CurrencyPair, period KlinePeriod, opts ...OptionParameter) ([]Kline, []byte, error) {
 	params := url.Values{}
 	params.Set("limit", "1000")
@@ -78,8 +106,83 @@ func (s *Spot) GetKline(pair CurrencyPair, period KlinePeriod, opts ...OptionPar
 	return klines, respBody, err
 }
 
+// GetTrades 最近成交, 按时间升序, limit最大1000
+func (s *Spot) GetTrades(pair CurrencyPair, limit int, opts ...OptionParameter) ([]Trade, []byte, error) {
+	params := url.Values{}
+	params.Set("symbol", pair.Symbol)
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(min(limit, 1000)))
+	}
+	MergeOptionParams(&params, opts...)
+
+	reqUrl := fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetTradesUri)
+	respBody, err := s.DoNoAuthRequest(http.MethodGet, reqUrl, &params, nil)
+	if err != nil {
+		return nil, respBody, err
+	}
+
+	trades, err := s.UnmarshalerOpts.GetTradesResponseUnmarshaler(respBody)
+	if err != nil {
+		return nil, respBody, err
+	}
+
+	for i := range trades {
+		trades[i].Pair = pair
+	}
+
+	return trades, respBody, nil
+}
+
+// GetHistoryTrades 归集成交, 返回ID小于afterId的limit条记录, 按时间升序
+func (s *Spot) GetHistoryTrades(pair CurrencyPair, afterId string, limit int, opts ...OptionParameter) ([]Trade, []byte, error) {
+	params := url.Values{}
+	params.Set("symbol", pair.Symbol)
+	common.AdaptAggTradesPageParams(&params, afterId, limit)
+	MergeOptionParams(&params, opts...)
+
+	reqUrl := fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetHistoryTradesUri)
+	respBody, err := s.DoNoAuthRequest(http.MethodGet, reqUrl, &params, nil)
+	if err != nil {
+		return nil, respBody, err
+	}
+
+	trades, err := s.UnmarshalerOpts.GetHistoryTradesResponseUnmarshaler(respBody)
+	if err != nil {
+		return nil, respBody, err
+	}
+
+	for i := range trades {
+		trades[i].Pair = pair
+	}
+
+	return trades, respBody, nil
+}
+
 func (s *Spot) GetExchangeInfo() (map[string]CurrencyPair, []byte, error) {
-	panic("not implement")
+	params := url.Values{}
+	respBody, err := s.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.UriOpts.Endpoint, s.UriOpts.GetExchangeInfoUri), &params, nil)
+	if err != nil {
+		logger.Errorf("[GetExchangeInfo] http request error, body: %s", string(respBody))
+		return nil, respBody, err
+	}
+
+	m, err := s.UnmarshalerOpts.GetExchangeInfoResponseUnmarshaler(respBody)
+	if err != nil {
+		return nil, respBody, err
+	}
+
+	s.currencyPairM = m
+
+	return m, respBody, nil
+}
+
+func (s *Spot) NewCurrencyPair(baseSym, quoteSym string, opts ...OptionParameter) (CurrencyPair, error) {
+	currencyPair := s.currencyPairM[baseSym+quoteSym]
+	if currencyPair.Symbol == "" {
+		return currencyPair, errors.New("not found currency pair")
+	}
+	return currencyPair, nil
 }
 
 func (s *Spot) DoNoAuthRequest(method, reqUrl string, params *url.Values, headers map[string]string) ([]byte, error) {
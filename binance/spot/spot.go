@@ -12,31 +12,63 @@ var (
 type Spot struct {
 	UnmarshalerOpts UnmarshalerOptions
 	UriOpts         UriOptions
+	currencyPairM   map[string]CurrencyPair
 }
 
 func New() *Spot {
 	unmarshaler := new(RespUnmarshaler)
 	s := &Spot{
 		UriOpts: UriOptions{
-			Endpoint:            "https://api.binance.com",
-			TickerUri:           "/api/v3/ticker/24hr",
-			DepthUri:            "/api/v3/depth",
-			KlineUri:            "/api/v3/klines",
-			NewOrderUri:         "/api/v3/order",
-			GetPendingOrdersUri: "/api/v3/openOrders",
-			CancelOrderUri:      "/api/v3/order",
-			GetOrderUri:         "/api/v3/order",
-			GetHistoryOrdersUri: "/api/v3/allOrders",
+			Endpoint:                "https://api.binance.com",
+			WsEndpoint:              "wss://stream.binance.com:9443/ws",
+			WsPrivateEndpoint:       "wss://ws-api.binance.com:443/ws-api/v3",
+			TickerUri:               "/api/v3/ticker/24hr",
+			DepthUri:                "/api/v3/depth",
+			KlineUri:                "/api/v3/klines",
+			NewOrderUri:             "/api/v3/order",
+			GetPendingOrdersUri:     "/api/v3/openOrders",
+			CancelOrderUri:          "/api/v3/order",
+			GetOrderUri:             "/api/v3/order",
+			GetHistoryOrdersUri:     "/api/v3/allOrders",
+			GetExchangeInfoUri:      "/api/v3/exchangeInfo",
+			GetAccountUri:           "/api/v3/account",
+			CancelAllOrdersUri:      "/api/v3/openOrders",
+			NewAlgoOrderUri:         "/api/v3/orderList/oco",
+			GetAlgoOrderUri:         "/api/v3/orderList",
+			GetPendingAlgoOrdersUri: "/api/v3/openOrderList",
+			CancelAlgoOrderUri:      "/api/v3/orderList",
+			GetFillsUri:             "/api/v3/myTrades",
+			GetTradesUri:            "/api/v3/trades",
+			GetTickersUri:           "/api/v3/ticker/24hr",
+			GetServerTimeUri:        "/api/v3/time",
+			GetHistoryTradesUri:     "/api/v3/aggTrades",
+			TransferUri:             "/sapi/v1/asset/transfer",
+			GetTransferUri:          "/sapi/v1/asset/transfer",
 		},
 		UnmarshalerOpts: UnmarshalerOptions{
-			ResponseUnmarshaler:                 unmarshaler.UnmarshalResponse,
-			TickerUnmarshaler:                   unmarshaler.UnmarshalGetTickerResponse,
-			DepthUnmarshaler:                    unmarshaler.UnmarshalGetDepthResponse,
-			KlineUnmarshaler:                    unmarshaler.UnmarshalGetKlineResponse,
-			CreateOrderResponseUnmarshaler:      unmarshaler.UnmarshalCreateOrderResponse,
-			GetPendingOrdersResponseUnmarshaler: unmarshaler.UnmarshalGetPendingOrdersResponse,
-			CancelOrderResponseUnmarshaler:      unmarshaler.UnmarshalCancelOrderResponse,
+			ResponseUnmarshaler:                     unmarshaler.UnmarshalResponse,
+			TickerUnmarshaler:                       unmarshaler.UnmarshalGetTickerResponse,
+			DepthUnmarshaler:                        unmarshaler.UnmarshalGetDepthResponse,
+			KlineUnmarshaler:                        unmarshaler.UnmarshalGetKlineResponse,
+			CreateOrderResponseUnmarshaler:          unmarshaler.UnmarshalCreateOrderResponse,
+			GetPendingOrdersResponseUnmarshaler:     unmarshaler.UnmarshalGetPendingOrdersResponse,
+			CancelOrderResponseUnmarshaler:          unmarshaler.UnmarshalCancelOrderResponse,
+			GetExchangeInfoResponseUnmarshaler:      unmarshaler.UnmarshalGetExchangeInfoResponse,
+			GetAccountResponseUnmarshaler:           unmarshaler.UnmarshalGetAccountResponse,
+			GetOrderInfoResponseUnmarshaler:         unmarshaler.UnmarshalGetOrderInfoResponse,
+			CreateAlgoOrderResponseUnmarshaler:      unmarshaler.UnmarshalGetAlgoOrderInfoResponse,
+			GetAlgoOrderInfoResponseUnmarshaler:     unmarshaler.UnmarshalGetAlgoOrderInfoResponse,
+			GetPendingAlgoOrdersResponseUnmarshaler: unmarshaler.UnmarshalGetPendingAlgoOrdersResponse,
+			CancelAlgoOrderResponseUnmarshaler:      unmarshaler.UnmarshalCancelOrderResponse,
+			GetFillsResponseUnmarshaler:             unmarshaler.UnmarshalGetFillsResponse,
+			GetTradesResponseUnmarshaler:            unmarshaler.UnmarshalGetTradesResponse,
+			GetTickersResponseUnmarshaler:           unmarshaler.UnmarshalGetTickersResponse,
+			GetServerTimeResponseUnmarshaler:        unmarshaler.UnmarshalGetServerTimeResponse,
+			GetHistoryTradesResponseUnmarshaler:     unmarshaler.UnmarshalGetAggTradesResponse,
+			TransferResponseUnmarshaler:             unmarshaler.UnmarshalTransferResponse,
+			GetTransfersResponseUnmarshaler:         unmarshaler.UnmarshalGetTransfersResponse,
 		},
+		currencyPairM: make(map[string]CurrencyPair, 64),
 	}
 	return s
 }
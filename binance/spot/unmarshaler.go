@@ -3,8 +3,10 @@ package spot
 import (
 	"encoding/json"
 	"github.com/buger/jsonparser"
+	"github.com/shadowors/goex/v2/binance/common"
 	"github.com/shadowors/goex/v2/logger"
 	. "github.com/shadowors/goex/v2/model"
+	. "github.com/shadowors/goex/v2/util"
 	"github.com/spf13/cast"
 )
 
@@ -54,16 +56,51 @@ func (u *RespUnmarshaler) UnmarshalGetTickerResponse(data []byte) (*Ticker, erro
 		return tk, nil
 	}
 
-	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+	err := unmarshalTicker(data, tk)
+	if err != nil {
+		logger.Errorf("[UnmarshalTicker] %s", err.Error())
+		return nil, err
+	}
+
+	return tk, nil
+
+}
+
+// UnmarshalGetTickersResponse key为symbol
+func (u *RespUnmarshaler) UnmarshalGetTickersResponse(data []byte) (map[string]Ticker, error) {
+	var tickers = make(map[string]Ticker, 512)
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var tk Ticker
+		if er := unmarshalTicker(value, &tk); er != nil {
+			logger.Errorf("[UnmarshalGetTickersResponse] %s", er.Error())
+			return
+		}
+		tickers[tk.Pair.Symbol] = tk
+	})
+
+	return tickers, err
+}
+
+func unmarshalTicker(data []byte, tk *Ticker) error {
+	return jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
 		switch string(key) {
+		case "symbol":
+			tk.Pair.Symbol = string(value)
 		case "lastPrice":
 			tk.Last = cast.ToFloat64(string(value))
 		case "askPrice":
 			tk.Sell = cast.ToFloat64(string(value))
+		case "askQty":
+			tk.SellQty = cast.ToFloat64(string(value))
 		case "bidPrice":
 			tk.Buy = cast.ToFloat64(string(value))
+		case "bidQty":
+			tk.BuyQty = cast.ToFloat64(string(value))
 		case "volume":
 			tk.Vol = cast.ToFloat64(string(value))
+		case "quoteVolume":
+			tk.QuoteVol = cast.ToFloat64(string(value))
 		case "highPrice":
 			tk.High = cast.ToFloat64(string(value))
 		case "lowPrice":
@@ -75,13 +112,10 @@ func (u *RespUnmarshaler) UnmarshalGetTickerResponse(data []byte) (*Ticker, erro
 		}
 		return nil
 	})
-	if err != nil {
-		logger.Errorf("[UnmarshalTicker] %s", err.Error())
-		return nil, err
-	}
-
-	return tk, nil
+}
 
+func (u *RespUnmarshaler) UnmarshalGetServerTimeResponse(data []byte) (int64, error) {
+	return jsonparser.GetInt(data, "serverTime")
 }
 
 func (u *RespUnmarshaler) UnmarshalGetKlineResponse(data []byte) ([]Kline, error) {
@@ -159,6 +193,11 @@ func (u *RespUnmarshaler) UnmarshalGetPendingOrdersResponse(data []byte) ([]Orde
 	return orders, err
 }
 
+func (u *RespUnmarshaler) UnmarshalGetOrderInfoResponse(data []byte) (*Order, error) {
+	ord, err := u.unmarshalOrderResponse(data)
+	return &ord, err
+}
+
 func (u *RespUnmarshaler) unmarshalOrderResponse(data []byte) (ord Order, err error) {
 	err = jsonparser.ObjectEach(data, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
 		valStr := string(val)
@@ -171,7 +210,7 @@ func (u *RespUnmarshaler) unmarshalOrderResponse(data []byte) (ord Order, err er
 			ord.Price = cast.ToFloat64(valStr)
 		case "origQty":
 			ord.Qty = cast.ToFloat64(valStr)
-		case "executeQty":
+		case "executedQty":
 			ord.ExecutedQty = cast.ToFloat64(valStr)
 		case "time":
 			ord.CanceledAt = cast.ToInt64(valStr)
@@ -187,10 +226,277 @@ func (u *RespUnmarshaler) unmarshalOrderResponse(data []byte) (ord Order, err er
 	return
 }
 
+func (u *RespUnmarshaler) UnmarshalGetPendingAlgoOrdersResponse(data []byte) ([]Order, error) {
+	var orders []Order
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		ord, err := u.UnmarshalGetAlgoOrderInfoResponse(value)
+		if err != nil {
+			logger.Warnf("[UnmarshalGetPendingAlgoOrdersResponse] err=%s", err.Error())
+			return
+		}
+		orders = append(orders, *ord)
+	})
+	return orders, err
+}
+
+// UnmarshalGetAlgoOrderInfoResponse OCO订单组(orderList), 不包含价格信息. Pair只设置了Symbol
+func (u *RespUnmarshaler) UnmarshalGetAlgoOrderInfoResponse(data []byte) (*Order, error) {
+	var ord = &Order{OrderTy: OrderType_OCO}
+	err := jsonparser.ObjectEach(data, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+		valStr := string(val)
+		switch string(key) {
+		case "orderListId":
+			ord.Id = valStr
+		case "listClientOrderId":
+			ord.CId = valStr
+		case "symbol":
+			ord.Pair.Symbol = valStr
+		case "transactionTime":
+			ord.CreatedAt = cast.ToInt64(valStr)
+		case "listOrderStatus":
+			ord.Status = adaptOrderListStatus(valStr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ord, nil
+}
+
+func (u *RespUnmarshaler) UnmarshalGetTradesResponse(data []byte) ([]Trade, error) {
+	var trades []Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			trade      Trade
+			buyerMaker bool
+		)
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "id":
+				trade.Id = valStr
+			case "price":
+				trade.Price = cast.ToFloat64(valStr)
+			case "qty":
+				trade.Qty = cast.ToFloat64(valStr)
+			case "time":
+				trade.Ts = cast.ToInt64(valStr)
+			case "isBuyerMaker":
+				buyerMaker = valStr == "true"
+			}
+			return nil
+		})
+		trade.Side = common.AdaptBuyerMakerToSide(buyerMaker)
+		trades = append(trades, trade)
+	})
+	return trades, err
+}
+
+func (u *RespUnmarshaler) UnmarshalGetAggTradesResponse(data []byte) ([]Trade, error) {
+	var trades []Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			trade      Trade
+			buyerMaker bool
+		)
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "a":
+				trade.Id = valStr
+			case "p":
+				trade.Price = cast.ToFloat64(valStr)
+			case "q":
+				trade.Qty = cast.ToFloat64(valStr)
+			case "T":
+				trade.Ts = cast.ToInt64(valStr)
+			case "m":
+				buyerMaker = valStr == "true"
+			}
+			return nil
+		})
+		trade.Side = common.AdaptBuyerMakerToSide(buyerMaker)
+		trades = append(trades, trade)
+	})
+	return trades, err
+}
+
+func (u *RespUnmarshaler) UnmarshalGetFillsResponse(data []byte) ([]Trade, error) {
+	var fills []Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var fill Trade
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "symbol":
+				fill.Pair.Symbol = valStr
+			case "id":
+				fill.Id = valStr
+			case "orderId":
+				fill.OrderId = valStr
+			case "price":
+				fill.Price = cast.ToFloat64(valStr)
+			case "qty":
+				fill.Qty = cast.ToFloat64(valStr)
+			case "commission":
+				fill.Fee = cast.ToFloat64(valStr)
+			case "commissionAsset":
+				fill.FeeCcy = valStr
+			case "isMaker":
+				fill.IsMaker = valStr == "true"
+			case "isBuyer":
+				if valStr == "true" {
+					fill.Side = Spot_Buy
+				} else {
+					fill.Side = Spot_Sell
+				}
+			case "time":
+				fill.Ts = cast.ToInt64(valStr)
+			}
+			return nil
+		})
+		fills = append(fills, fill)
+	})
+	return fills, err
+}
+
 func (u *RespUnmarshaler) UnmarshalCancelOrderResponse(data []byte) error {
 	return nil
 }
 
+func (u *RespUnmarshaler) UnmarshalGetExchangeInfoResponse(data []byte) (map[string]CurrencyPair, error) {
+	var currencyPairM = make(map[string]CurrencyPair, 64)
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var currencyPair CurrencyPair
+
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "symbol":
+				currencyPair.Symbol = valStr
+			case "baseAsset":
+				currencyPair.BaseSymbol = valStr
+			case "quoteAsset":
+				currencyPair.QuoteSymbol = valStr
+			case "filters":
+				_, err := jsonparser.ArrayEach(val, func(filterData []byte, dataType jsonparser.ValueType, offset int, err error) {
+					filterType, _ := jsonparser.GetString(filterData, "filterType")
+					switch filterType {
+					case "PRICE_FILTER":
+						tickSize, _ := jsonparser.GetString(filterData, "tickSize")
+						currencyPair.TickSize = cast.ToFloat64(tickSize)
+						currencyPair.PricePrecision = PrecisionOf(tickSize)
+					case "LOT_SIZE":
+						minQty, _ := jsonparser.GetString(filterData, "minQty")
+						maxQty, _ := jsonparser.GetString(filterData, "maxQty")
+						stepSize, _ := jsonparser.GetString(filterData, "stepSize")
+						currencyPair.MinQty = cast.ToFloat64(minQty)
+						currencyPair.MaxQty = cast.ToFloat64(maxQty)
+						currencyPair.LotSize = cast.ToFloat64(stepSize)
+						currencyPair.QtyPrecision = PrecisionOf(stepSize)
+					case "MARKET_LOT_SIZE":
+						maxQty, _ := jsonparser.GetString(filterData, "maxQty")
+						currencyPair.MarketQty = cast.ToFloat64(maxQty)
+					case "MIN_NOTIONAL", "NOTIONAL":
+						minNotional, _ := jsonparser.GetString(filterData, "minNotional")
+						currencyPair.MinNotional = cast.ToFloat64(minNotional)
+					case "PERCENT_PRICE":
+						multiplierUp, _ := jsonparser.GetString(filterData, "multiplierUp")
+						multiplierDown, _ := jsonparser.GetString(filterData, "multiplierDown")
+						currencyPair.MultiplierUp = cast.ToFloat64(multiplierUp)
+						currencyPair.MultiplierDown = cast.ToFloat64(multiplierDown)
+					}
+				})
+				return err
+			}
+			return nil
+		})
+
+		if err != nil {
+			logger.Warnf("[UnmarshalGetExchangeInfoResponse] err=%s", err.Error())
+			return
+		}
+
+		currencyPairM[currencyPair.BaseSymbol+currencyPair.QuoteSymbol] = currencyPair
+	}, "symbols")
+
+	return currencyPairM, err
+}
+
+func (u *RespUnmarshaler) UnmarshalGetAccountResponse(data []byte) (map[string]Account, error) {
+	var accounts = make(map[string]Account, 4)
+	balancesData, _, _, err := jsonparser.Get(data, "balances")
+	if err != nil {
+		return nil, err
+	}
+	_, err = jsonparser.ArrayEach(balancesData, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var acc Account
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "asset":
+				acc.Coin = valStr
+			case "free":
+				acc.AvailableBalance = cast.ToFloat64(valStr)
+			case "locked":
+				acc.FrozenBalance = cast.ToFloat64(valStr)
+			}
+			return nil
+		})
+		acc.Balance = acc.AvailableBalance + acc.FrozenBalance
+		accounts[acc.Coin] = acc
+	})
+	return accounts, err
+}
+
+// UnmarshalTransferResponse 只返回tranId, 最终状态需查询划转历史
+func (u *RespUnmarshaler) UnmarshalTransferResponse(data []byte) (*Transfer, error) {
+	tranId, err := jsonparser.GetInt(data, "tranId")
+	if err != nil {
+		return nil, err
+	}
+	return &Transfer{Id: cast.ToString(tranId), Status: TransferStatus_Pending}, nil
+}
+
+func (u *RespUnmarshaler) UnmarshalGetTransfersResponse(data []byte) ([]Transfer, error) {
+	var transfers []Transfer
+
+	rowsData, dataType, _, err := jsonparser.Get(data, "rows")
+	if dataType == jsonparser.NotExist {
+		return transfers, nil //没有划转记录时不返回rows
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = jsonparser.ArrayEach(rowsData, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var transfer Transfer
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "tranId":
+				transfer.Id = valStr
+			case "asset":
+				transfer.Ccy = valStr
+			case "amount":
+				transfer.Amount = cast.ToFloat64(valStr)
+			case "type":
+				transfer.From, transfer.To = adaptSymToTransferType(valStr)
+			case "status":
+				transfer.Status = adaptTransferStatus(valStr)
+			case "timestamp":
+				transfer.Ts = cast.ToInt64(valStr)
+			}
+			return nil
+		})
+		transfers = append(transfers, transfer)
+	})
+
+	return transfers, err
+}
+
 func (u *RespUnmarshaler) UnmarshalResponse(data []byte, res interface{}) error {
 	return json.Unmarshal(data, res)
 }
@@ -0,0 +1,15 @@
+package goex
+
+import "github.com/shadowors/goex/v2/util"
+
+// NewServerClock 以交易所服务器时间校正的时钟, 配合options.WithClock用于签名
+//
+//	clock := goex.NewServerClock(goex.OKx.Spot)
+//	clock.Start(time.Minute)
+//	prv := goex.OKx.Spot.NewPrvApi(options.WithClock(clock), ...)
+func NewServerClock(api IPubRest) *util.Clock {
+	return util.NewClock(func() (int64, error) {
+		ts, _, err := api.GetServerTime()
+		return ts, err
+	})
+}
@@ -0,0 +1,182 @@
+package goex
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/util"
+)
+
+// CoinQtyFuturesPrvApi 统一以币数量下单, 并以币数量返回订单/成交/持仓,
+// 内部按CurrencyPair.ContractVal换算成交易所的下单单位(张), 张数按LotSize向下取整.
+// 币本位合约换算需要价格: 优先使用委托价格/成交均价, 没有价格时(市价单)使用price获取
+//
+//	api := goex.NewCoinQtyFuturesPrvApi(goex.OKx.Swap.NewPrvApi(...), validator.CachedPrice(lastPrice, 3*time.Second))
+//	api.CreateOrder(btcUSDTSwap, 0.5, 0, model.Futures_OpenBuy, model.OrderType_Market) //0.5 BTC = 50张
+type CoinQtyFuturesPrvApi struct {
+	IFuturesPrvRest
+	price func(pair model.CurrencyPair) (float64, error)
+}
+
+// NewCoinQtyFuturesPrvApi price可为nil, 此时币本位合约的市价单返回错误
+func NewCoinQtyFuturesPrvApi(api IFuturesPrvRest, price func(pair model.CurrencyPair) (float64, error)) *CoinQtyFuturesPrvApi {
+	return &CoinQtyFuturesPrvApi{IFuturesPrvRest: api, price: price}
+}
+
+func (c *CoinQtyFuturesPrvApi) CreateOrder(pair model.CurrencyPair, qty, price float64, side model.OrderSide, orderTy model.OrderType, opt ...model.OptionParameter) (*model.Order, []byte, error) {
+	contracts, err := c.toContracts(pair, qty, price)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ord, responseBody, err := c.IFuturesPrvRest.CreateOrder(pair, contracts, price, side, orderTy, opt...)
+	c.orderToQty(pair, ord)
+	return ord, responseBody, err
+}
+
+func (c *CoinQtyFuturesPrvApi) GetOrderInfo(pair model.CurrencyPair, id string, opt ...model.OptionParameter) (*model.Order, []byte, error) {
+	ord, responseBody, err := c.IFuturesPrvRest.GetOrderInfo(pair, id, opt...)
+	c.orderToQty(pair, ord)
+	return ord, responseBody, err
+}
+
+func (c *CoinQtyFuturesPrvApi) GetPendingOrders(pair model.CurrencyPair, opt ...model.OptionParameter) ([]model.Order, []byte, error) {
+	orders, responseBody, err := c.IFuturesPrvRest.GetPendingOrders(pair, opt...)
+	for i := range orders {
+		c.orderToQty(pair, &orders[i])
+	}
+	return orders, responseBody, err
+}
+
+func (c *CoinQtyFuturesPrvApi) GetHistoryOrders(pair model.CurrencyPair, opt ...model.OptionParameter) ([]model.Order, []byte, error) {
+	orders, responseBody, err := c.IFuturesPrvRest.GetHistoryOrders(pair, opt...)
+	for i := range orders {
+		c.orderToQty(pair, &orders[i])
+	}
+	return orders, responseBody, err
+}
+
+// CreateOrders 换算失败的订单不发送, 错误见对应的OrderResult.Err
+func (c *CoinQtyFuturesPrvApi) CreateOrders(reqs []model.OrderRequest, opt ...model.OptionParameter) ([]model.OrderResult, []byte, error) {
+	var (
+		results  = make([]model.OrderResult, len(reqs))
+		sendReqs = make([]model.OrderRequest, 0, len(reqs))
+		sendIdx  = make([]int, 0, len(reqs))
+	)
+
+	for i, req := range reqs {
+		contracts, err := c.toContracts(req.Pair, req.Qty, req.Price)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		req.Qty = contracts
+		sendReqs = append(sendReqs, req)
+		sendIdx = append(sendIdx, i)
+	}
+
+	if len(sendReqs) == 0 {
+		return results, nil, nil
+	}
+
+	sendResults, responseBody, err := c.IFuturesPrvRest.CreateOrders(sendReqs, opt...)
+	for j, r := range sendResults {
+		if j >= len(sendIdx) {
+			break
+		}
+		i := sendIdx[j]
+		c.orderToQty(reqs[i].Pair, r.Order)
+		results[i] = r
+	}
+
+	return results, responseBody, err
+}
+
+func (c *CoinQtyFuturesPrvApi) AmendOrder(pair model.CurrencyPair, id string, newQty, newPrice float64, opt ...model.OptionParameter) (*model.AmendResult, []byte, error) {
+	if newQty > 0 {
+		contracts, err := c.toContracts(pair, newQty, newPrice)
+		if err != nil {
+			return nil, nil, err
+		}
+		newQty = contracts
+	}
+
+	result, responseBody, err := c.IFuturesPrvRest.AmendOrder(pair, id, newQty, newPrice, opt...)
+	if result != nil {
+		c.orderToQty(pair, result.Order)
+	}
+	return result, responseBody, err
+}
+
+func (c *CoinQtyFuturesPrvApi) GetFills(pair model.CurrencyPair, since int64, limit int, opt ...model.OptionParameter) ([]model.Trade, []byte, error) {
+	fills, responseBody, err := c.IFuturesPrvRest.GetFills(pair, since, limit, opt...)
+	for i := range fills {
+		fills[i].Qty = c.toQty(pair, fills[i].Qty, fills[i].Price)
+	}
+	return fills, responseBody, err
+}
+
+func (c *CoinQtyFuturesPrvApi) GetPositions(pair model.CurrencyPair, opts ...model.OptionParameter) ([]model.FuturesPosition, []byte, error) {
+	positions, responseBody, err := c.IFuturesPrvRest.GetPositions(pair, opts...)
+	for i := range positions {
+		p := &positions[i]
+		posPair := pair
+		if posPair.ContractVal <= 0 {
+			posPair = p.Pair
+		}
+		p.Qty = c.toQty(posPair, p.Qty, p.AvgPx)
+		p.AvailQty = c.toQty(posPair, p.AvailQty, p.AvgPx)
+	}
+	return positions, responseBody, err
+}
+
+func (c *CoinQtyFuturesPrvApi) toContracts(pair model.CurrencyPair, qty, price float64) (float64, error) {
+	if pair.ContractVal <= 0 {
+		return qty, nil
+	}
+
+	if util.IsQuoteValued(pair) && price <= 0 {
+		if c.price == nil {
+			return 0, errors.New("coin qty of inverse contract need price")
+		}
+		var err error
+		if price, err = c.price(pair); err != nil {
+			return 0, err
+		}
+	}
+
+	contracts := util.RoundToStep(util.QtyToContracts(pair, qty, price), pair.LotSize, util.RoundingMode_Down)
+	if contracts <= 0 {
+		return 0, fmt.Errorf("%w: %v %s less than 1 lot", util.ErrQtyTooSmall, qty, pair.BaseSymbol)
+	}
+
+	return contracts, nil
+}
+
+// toQty 币本位合约获取价格失败时返回0
+func (c *CoinQtyFuturesPrvApi) toQty(pair model.CurrencyPair, contracts, price float64) float64 {
+	if contracts == 0 || pair.ContractVal <= 0 {
+		return contracts
+	}
+
+	if util.IsQuoteValued(pair) && price <= 0 && c.price != nil {
+		price, _ = c.price(pair)
+	}
+
+	return util.ContractsToQty(pair, contracts, price)
+}
+
+func (c *CoinQtyFuturesPrvApi) orderToQty(pair model.CurrencyPair, ord *model.Order) {
+	if ord == nil {
+		return
+	}
+
+	price := ord.PriceAvg
+	if price <= 0 {
+		price = ord.Price
+	}
+
+	ord.Qty = c.toQty(pair, ord.Qty, price)
+	ord.ExecutedQty = c.toQty(pair, ord.ExecutedQty, price)
+}
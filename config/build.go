@@ -0,0 +1,225 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shadowors/goex/v2"
+	"github.com/shadowors/goex/v2/httpcli"
+	"github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/options"
+)
+
+const defaultPassphraseEnv = "GOEX_CREDENTIALS_PASSPHRASE"
+
+// Client 按账户配置创建的客户端, 每个账户使用独立的api实例, 互不影响endpoint等配置
+type Client struct {
+	Name  string
+	Venue string //注册的交易所市场名称, 如binance.usdm
+	Pub   goex.IPubRest
+	Prv   goex.IPrvRest //未配置密钥时为nil
+}
+
+// Load 解析配置文件并创建所有账户的客户端
+func Load(path string) (map[string]*Client, error) {
+	cfg, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Build()
+}
+
+// Build 设置全局http代理/超时, 加载加密凭证文件, 按账户创建客户端, key为账户名.
+// 凭证优先级: 环境变量 > 配置文件 > 加密凭证文件
+func (cfg *Config) Build() (map[string]*Client, error) {
+	if cfg.Proxy != "" {
+		if err := httpcli.Cli.SetProxy(cfg.Proxy); err != nil {
+			return nil, fmt.Errorf("set proxy error: %w", err)
+		}
+	}
+
+	if cfg.Timeout > 0 {
+		httpcli.Cli.SetTimeout(cfg.Timeout)
+	}
+
+	creds, err := cfg.loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make(map[string]*Client, len(cfg.Accounts))
+
+	for _, acc := range cfg.Accounts {
+		if acc.Name == "" {
+			return nil, errors.New("account name is empty")
+		}
+
+		if _, ok := clients[acc.Name]; ok {
+			return nil, fmt.Errorf("duplicate account name: %s", acc.Name)
+		}
+
+		credName := acc.Credential
+		if credName == "" {
+			credName = acc.Name
+		}
+		if cred, ok := creds[credName]; ok {
+			fillEmpty(&acc.Key, cred.Key)
+			fillEmpty(&acc.Secret, cred.Secret)
+			fillEmpty(&acc.Passphrase, cred.Passphrase)
+			fillEmpty(&acc.PrivateKey, cred.PrivateKey)
+		} else if acc.Credential != "" {
+			return nil, fmt.Errorf("[%s] credential %s not found", acc.Name, acc.Credential)
+		}
+
+		cli, err := acc.build()
+		if err != nil {
+			return nil, fmt.Errorf("[%s] %w", acc.Name, err)
+		}
+
+		clients[acc.Name] = cli
+	}
+
+	return clients, nil
+}
+
+func (cfg *Config) loadCredentials() (map[string]Credential, error) {
+	if cfg.Credentials.File == "" {
+		return nil, nil
+	}
+
+	passphraseEnv := cfg.Credentials.PassphraseEnv
+	if passphraseEnv == "" {
+		passphraseEnv = defaultPassphraseEnv
+	}
+
+	passphrase := os.Getenv(passphraseEnv)
+	if passphrase == "" {
+		return nil, fmt.Errorf("credentials passphrase env %s is empty", passphraseEnv)
+	}
+
+	return LoadCredentialFile(cfg.Credentials.File, passphrase)
+}
+
+// marketAliases 兼容exchange+market写法, 映射到注册的venue名称
+var marketAliases = map[string]string{
+	"binance.futures": model.BINANCE_USDM,
+	"binance.swap":    model.BINANCE_USDM,
+	"huobi.futures":   model.HUOBI_LINEAR_SWAP,
+	"huobi.swap":      model.HUOBI_LINEAR_SWAP,
+}
+
+// venueName 优先使用venue, 否则由exchange.market得到
+func (acc AccountConfig) venueName() string {
+	if acc.Venue != "" {
+		return strings.ToLower(acc.Venue)
+	}
+	name := strings.ToLower(acc.Exchange + "." + acc.Market)
+	if alias, ok := marketAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+func (acc AccountConfig) build() (*Client, error) {
+	venueName := acc.venueName()
+	venue, ok := goex.LookupVenue(venueName)
+	if !ok {
+		return nil, fmt.Errorf("venue %s not registered", venueName)
+	}
+
+	var uriOpts []options.UriOption
+	if acc.Environment != "" {
+		if venue.WithEnvironment == nil {
+			return nil, fmt.Errorf("venue %s not support environment", venueName)
+		}
+		uriOpts = append(uriOpts, venue.WithEnvironment(options.Environment(acc.Environment)))
+	}
+	uriOpts = append(uriOpts, acc.uriOptions()...)
+
+	apiOpts, err := acc.apiOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	cli := &Client{Name: acc.Name, Venue: venueName, Pub: venue.NewPub(uriOpts...)}
+
+	if len(apiOpts) > 0 {
+		cli.Prv, err = goex.NewPrv(venueName, uriOpts, apiOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cli, nil
+}
+
+// uriOptions endpoint覆盖, 在WithEnvironment之后应用
+func (acc AccountConfig) uriOptions() []options.UriOption {
+	var opts []options.UriOption
+
+	if acc.Endpoint != "" {
+		opts = append(opts, options.WithEndpoint(acc.Endpoint))
+	}
+
+	if acc.WsEndpoint != "" {
+		opts = append(opts, options.WithWsEndpoint(acc.WsEndpoint))
+	}
+
+	if acc.WsPrivateEndpoint != "" {
+		opts = append(opts, options.WithWsPrivateEndpoint(acc.WsPrivateEndpoint))
+	}
+
+	if acc.SpotEndpoint != "" {
+		opts = append(opts, options.WithSpotEndpoint(acc.SpotEndpoint))
+	}
+
+	return opts
+}
+
+// apiOptions 未配置任何密钥时返回空
+func (acc AccountConfig) apiOptions() ([]options.ApiOption, error) {
+	var opts []options.ApiOption
+
+	if acc.Key != "" {
+		opts = append(opts, options.WithApiKey(acc.Key))
+	}
+
+	if acc.Secret != "" {
+		opts = append(opts, options.WithApiSecretKey(acc.Secret))
+	}
+
+	if acc.Passphrase != "" {
+		opts = append(opts, options.WithPassphrase(acc.Passphrase))
+	}
+
+	if acc.PrivateKey != "" {
+		opts = append(opts, options.WithPrivateKey(acc.PrivateKey))
+	}
+
+	if acc.PrivateKeyFile != "" {
+		opts = append(opts, options.WithPrivateKeyFile(acc.PrivateKeyFile))
+	}
+
+	if len(opts) == 0 {
+		return nil, nil
+	}
+
+	if acc.RecvWindow != "" {
+		recvWindow, err := time.ParseDuration(acc.RecvWindow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recv_window %s: %w", acc.RecvWindow, err)
+		}
+		opts = append(opts, options.WithRecvWindow(recvWindow))
+	}
+
+	return opts, nil
+}
+
+func fillEmpty(field *string, v string) {
+	if *field == "" {
+		*field = v
+	}
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config 账户配置, 支持yaml/json/toml, 例:
+//
+//	proxy: socks5://127.0.0.1:1080
+//	credentials:
+//	  file: ./credentials.enc
+//	accounts:
+//	  - name: okx-main
+//	    exchange: okx
+//	    market: swap
+//	    environment: testnet
+//	  - name: binance-spot
+//	    exchange: binance
+//	    market: spot
+//	    private_key_file: ./binance_ed25519.pem
+//	  - name: binance-usdm
+//	    venue: binance.usdm
+type Config struct {
+	Proxy       string            `yaml:"proxy" json:"proxy" toml:"proxy"`       //全局http代理, 所有账户共用
+	Timeout     int64             `yaml:"timeout" json:"timeout" toml:"timeout"` //http超时(秒)
+	Credentials CredentialsConfig `yaml:"credentials" json:"credentials" toml:"credentials"`
+	Accounts    []AccountConfig   `yaml:"accounts" json:"accounts" toml:"accounts"`
+}
+
+// CredentialsConfig 加密凭证文件, 解密口令从环境变量读取, 不写入配置文件
+type CredentialsConfig struct {
+	File          string `yaml:"file" json:"file" toml:"file"`
+	PassphraseEnv string `yaml:"passphrase_env" json:"passphrase_env" toml:"passphrase_env"` //默认GOEX_CREDENTIALS_PASSPHRASE
+}
+
+type AccountConfig struct {
+	Name              string `yaml:"name" json:"name" toml:"name"`
+	Venue             string `yaml:"venue" json:"venue" toml:"venue"`          //见goex.Venues(), 如binance.usdm; 为空时使用exchange.market
+	Exchange          string `yaml:"exchange" json:"exchange" toml:"exchange"` //okx, binance, huobi
+	Market            string `yaml:"market" json:"market" toml:"market"`       //spot, futures, swap
+	Environment       string `yaml:"environment" json:"environment" toml:"environment"`
+	Endpoint          string `yaml:"endpoint" json:"endpoint" toml:"endpoint"`
+	WsEndpoint        string `yaml:"ws_endpoint" json:"ws_endpoint" toml:"ws_endpoint"`
+	WsPrivateEndpoint string `yaml:"ws_private_endpoint" json:"ws_private_endpoint" toml:"ws_private_endpoint"`
+	SpotEndpoint      string `yaml:"spot_endpoint" json:"spot_endpoint" toml:"spot_endpoint"` //见options.UriOptions.SpotEndpoint
+	RecvWindow        string `yaml:"recv_window" json:"recv_window" toml:"recv_window"`       //如: 5s
+
+	Credential     string `yaml:"credential" json:"credential" toml:"credential"` //加密凭证文件中的名称, 默认同name
+	Key            string `yaml:"key" json:"key" toml:"key"`
+	Secret         string `yaml:"secret" json:"secret" toml:"secret"`
+	Passphrase     string `yaml:"passphrase" json:"passphrase" toml:"passphrase"`
+	PrivateKey     string `yaml:"private_key" json:"private_key" toml:"private_key"`
+	PrivateKeyFile string `yaml:"private_key_file" json:"private_key_file" toml:"private_key_file"`
+}
+
+// LoadFile 按扩展名解析配置文件(.yaml/.yml/.json/.toml), 并应用环境变量覆盖
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// Parse format: yaml, yml, json, toml
+func Parse(data []byte, format string) (*Config, error) {
+	var (
+		cfg Config
+		err error
+	)
+
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, &cfg)
+	case "json":
+		err = json.Unmarshal(data, &cfg)
+	case "toml":
+		err = toml.Unmarshal(data, &cfg)
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.applyEnv()
+
+	return &cfg, nil
+}
+
+// applyEnv 环境变量覆盖配置:
+//
+//	GOEX_PROXY
+//	GOEX_<NAME>_KEY, _SECRET, _PASSPHRASE, _PRIVATE_KEY, _PRIVATE_KEY_FILE, _ENDPOINT, _ENVIRONMENT
+//
+// NAME为账户名转大写, 非字母数字替换为下划线, 如okx-main -> GOEX_OKX_MAIN_KEY
+func (cfg *Config) applyEnv() {
+	overrideEnv(&cfg.Proxy, "GOEX_PROXY")
+
+	for i := range cfg.Accounts {
+		acc := &cfg.Accounts[i]
+		prefix := envPrefix(acc.Name)
+		overrideEnv(&acc.Key, prefix+"KEY")
+		overrideEnv(&acc.Secret, prefix+"SECRET")
+		overrideEnv(&acc.Passphrase, prefix+"PASSPHRASE")
+		overrideEnv(&acc.PrivateKey, prefix+"PRIVATE_KEY")
+		overrideEnv(&acc.PrivateKeyFile, prefix+"PRIVATE_KEY_FILE")
+		overrideEnv(&acc.Endpoint, prefix+"ENDPOINT")
+		overrideEnv(&acc.SpotEndpoint, prefix+"SPOT_ENDPOINT")
+		overrideEnv(&acc.Environment, prefix+"ENVIRONMENT")
+	}
+}
+
+func envPrefix(name string) string {
+	return "GOEX_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, name) + "_"
+}
+
+func overrideEnv(field *string, key string) {
+	if v, ok := os.LookupEnv(key); ok {
+		*field = v
+	}
+}
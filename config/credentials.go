@@ -0,0 +1,161 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	credentialFileVersion = 1
+	pbkdf2Iterations      = 600000
+)
+
+// Credential 单个账户的密钥
+type Credential struct {
+	Key        string `json:"key"`
+	Secret     string `json:"secret,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+	PrivateKey string `json:"private_key,omitempty"` //PEM
+}
+
+// credentialFile 加密凭证文件: PBKDF2-SHA256由口令派生256位密钥, AES-GCM加密
+type credentialFile struct {
+	Version    int    `json:"version"`
+	Kdf        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptCredentials creds key为账户名, 与AccountConfig.Credential对应
+func EncryptCredentials(creds map[string]Credential, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("empty credentials passphrase")
+	}
+
+	plaintext, err := json.Marshal(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	f := credentialFile{
+		Version:    credentialFileVersion,
+		Kdf:        "pbkdf2-sha256",
+		Iterations: pbkdf2Iterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err = rand.Read(f.Salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(passphrase, f.Salt, f.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	f.Nonce = make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(f.Nonce); err != nil {
+		return nil, err
+	}
+	f.Ciphertext = gcm.Seal(nil, f.Nonce, plaintext, nil)
+
+	return json.MarshalIndent(f, "", "  ")
+}
+
+// DecryptCredentials 口令错误或文件被篡改时返回错误
+func DecryptCredentials(data []byte, passphrase string) (map[string]Credential, error) {
+	var f credentialFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+
+	if f.Version != credentialFileVersion || f.Kdf != "pbkdf2-sha256" {
+		return nil, fmt.Errorf("unsupported credential file version %d, kdf %s", f.Version, f.Kdf)
+	}
+
+	gcm, err := newGCM(passphrase, f.Salt, f.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, f.Nonce, f.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("decrypt credentials failed, wrong passphrase or corrupted file")
+	}
+
+	var creds map[string]Credential
+	err = json.Unmarshal(plaintext, &creds)
+	return creds, err
+}
+
+func LoadCredentialFile(path, passphrase string) (map[string]Credential, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptCredentials(data, passphrase)
+}
+
+// SaveCredentialFile 文件权限0600
+func SaveCredentialFile(path string, creds map[string]Credential, passphrase string) error {
+	data, err := EncryptCredentials(creds, passphrase)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func newGCM(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations <= 0 {
+		return nil, errors.New("invalid pbkdf2 iterations")
+	}
+	block, err := aes.NewCipher(pbkdf2SHA256([]byte(passphrase), salt, iterations, 32))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2SHA256 RFC 8018 PBKDF2, PRF为HMAC-SHA256
+func pbkdf2SHA256(password, salt []byte, iter, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	var (
+		buf = make([]byte, 4)
+		dk  = make([]byte, 0, numBlocks*hashLen)
+		u   = make([]byte, hashLen)
+	)
+
+	for block := 1; block <= numBlocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(buf, uint32(block))
+		prf.Write(buf)
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+
+		for n := 2; n <= iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = u[:0]
+			u = prf.Sum(u)
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+	}
+
+	return dk[:keyLen]
+}
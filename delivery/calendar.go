@@ -0,0 +1,178 @@
+package delivery
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shadowors/goex/v2/instrument"
+)
+
+// Alias 统一的交割合约别名, 各交易所的原始别名见NormalizeAlias
+type Alias string
+
+const (
+	Alias_ThisWeek    Alias = "this_week"
+	Alias_NextWeek    Alias = "next_week"
+	Alias_Quarter     Alias = "quarter"
+	Alias_NextQuarter Alias = "next_quarter"
+)
+
+var venueAliases = map[string]Alias{
+	"this_week":       Alias_ThisWeek,    //okx, huobi
+	"next_week":       Alias_NextWeek,    //okx, huobi
+	"quarter":         Alias_Quarter,     //okx, huobi
+	"next_quarter":    Alias_NextQuarter, //okx, huobi, binance
+	"current_quarter": Alias_Quarter,     //binance
+}
+
+// NormalizeAlias 交易所返回的原始别名(CurrencyPair.ContractAlias)转换为统一别名, 不区分大小写
+func NormalizeAlias(raw string) (Alias, bool) {
+	alias, ok := venueAliases[strings.ToLower(raw)]
+	return alias, ok
+}
+
+// Contract 一个未到期的交割合约
+type Contract struct {
+	instrument.Instrument
+	Alias Alias `json:"alias"`
+}
+
+// DaysToExpiry 距交割的天数(含小数), 已到期返回0
+func (c Contract) DaysToExpiry(now time.Time) float64 {
+	return DaysToExpiry(c.Expiry, now)
+}
+
+// Calendar 基于instrument.Registry的交割合约日历, 使用前需先加载对应交易所
+//
+//	calendar := delivery.NewCalendar(instrument.DefaultRegistry.AddVenue(model.OKX_FUTURES, goex.OKx.Futures))
+//	quarter, err := calendar.Resolve(model.OKX_FUTURES, "BTC", "USDT", delivery.Alias_Quarter, time.Now())
+type Calendar struct {
+	registry *instrument.Registry
+}
+
+func NewCalendar(registry *instrument.Registry) *Calendar {
+	return &Calendar{registry: registry}
+}
+
+// Contracts 交易所所有未到期的交割合约, 按交割时间升序
+func (c *Calendar) Contracts(venue string, now time.Time) []Contract {
+	var (
+		nowMs     = now.UnixMilli()
+		contracts []Contract
+	)
+
+	for _, inst := range c.registry.Instruments(venue) {
+		if inst.Type != instrument.Type_Futures || inst.Expiry <= nowMs {
+			continue
+		}
+		contracts = append(contracts, Contract{Instrument: inst})
+	}
+
+	slices.SortStableFunc(contracts, func(a, b Contract) int {
+		return cmp.Compare(a.Expiry, b.Expiry)
+	})
+
+	for i := range contracts {
+		contracts[i].Alias = c.alias(contracts, i, now)
+	}
+
+	return contracts
+}
+
+// Chain 同一标的(base/quote)未到期的交割合约, 按交割时间升序
+func (c *Calendar) Chain(venue, base, quote string, now time.Time) []Contract {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	return slices.DeleteFunc(c.Contracts(venue, now), func(ct Contract) bool {
+		return ct.Base != base || ct.Quote != quote
+	})
+}
+
+// Resolve 按统一别名查找交割合约, 各交易所使用相同的别名, 交易所不支持该别名时返回错误
+func (c *Calendar) Resolve(venue, base, quote string, alias Alias, now time.Time) (Contract, error) {
+	for _, ct := range c.Chain(venue, base, quote, now) {
+		if ct.Alias == alias {
+			return ct, nil
+		}
+	}
+	return Contract{}, fmt.Errorf("%s %s-%s %s contract not found", venue, base, quote, alias)
+}
+
+// Next 同一标的、结算币种下交割时间晚于cur的最近一个合约, 用于移仓
+func (c *Calendar) Next(venue string, cur instrument.Instrument, now time.Time) (Contract, error) {
+	for _, ct := range c.Chain(venue, cur.Base, cur.Quote, now) {
+		if ct.Settle == cur.Settle && ct.Expiry > cur.Expiry {
+			return ct, nil
+		}
+	}
+	return Contract{}, fmt.Errorf("%s no contract after %s", venue, cur.Id)
+}
+
+// Expiring 在within时间内到期的交割合约
+func (c *Calendar) Expiring(venue string, within time.Duration, now time.Time) []Contract {
+	deadline := now.Add(within).UnixMilli()
+	return slices.DeleteFunc(c.Contracts(venue, now), func(ct Contract) bool {
+		return ct.Expiry > deadline
+	})
+}
+
+// alias 优先使用交易所返回的别名, 交易所没有返回时按同一标的中的交割时间推算:
+// 7天内到期为this_week, 14天内为next_week, 之后依次为quarter, next_quarter
+func (c *Calendar) alias(contracts []Contract, i int, now time.Time) Alias {
+	if alias, ok := NormalizeAlias(contracts[i].Pair.ContractAlias); ok {
+		return alias
+	}
+
+	cur := contracts[i]
+	days := cur.DaysToExpiry(now)
+	switch {
+	case days <= 7:
+		return Alias_ThisWeek
+	case days <= 14:
+		return Alias_NextWeek
+	}
+
+	n := 0
+	for _, ct := range contracts[:i] {
+		if ct.Base == cur.Base && ct.Quote == cur.Quote && ct.Settle == cur.Settle && ct.DaysToExpiry(now) > 14 {
+			n++
+		}
+	}
+
+	switch n {
+	case 0:
+		return Alias_Quarter
+	case 1:
+		return Alias_NextQuarter
+	}
+
+	return ""
+}
+
+// DaysToExpiry 距交割的天数(含小数), expiry为毫秒, 已到期返回0
+func DaysToExpiry(expiry int64, now time.Time) float64 {
+	d := time.UnixMilli(expiry).Sub(now)
+	if d <= 0 {
+		return 0
+	}
+	return d.Hours() / 24
+}
+
+// Basis 期现基差率: (合约价格-现货价格)/现货价格
+func Basis(futuresPx, spotPx float64) float64 {
+	if spotPx <= 0 {
+		return 0
+	}
+	return (futuresPx - spotPx) / spotPx
+}
+
+// AnnualizedBasis 基差率按距交割天数年化, 已到期或缺少价格时返回0
+func AnnualizedBasis(futuresPx, spotPx float64, expiry int64, now time.Time) float64 {
+	days := DaysToExpiry(expiry, now)
+	if days <= 0 {
+		return 0
+	}
+	return Basis(futuresPx, spotPx) * 365 / days
+}
@@ -0,0 +1,218 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shadowors/goex/v2"
+	"github.com/shadowors/goex/v2/instrument"
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/util"
+)
+
+type RollOptions struct {
+	OrderTy  model.OrderType         //默认市价; 限价时需要Roller的price, 按Slippage偏移委托价格
+	Slippage float64                 //限价单相对最新价的偏移比例, 如0.001
+	Opts     []model.OptionParameter //每笔订单附加的参数, 如保证金模式
+}
+
+// RollResult 一个方向持仓的移仓结果
+type RollResult struct {
+	From    instrument.Instrument `json:"from"`
+	To      instrument.Instrument `json:"to"`
+	PosSide model.OrderSide       `json:"pos_side"`
+	Qty     float64               `json:"qty"`      //平仓数量(张)
+	OpenQty float64               `json:"open_qty"` //开仓数量(张), 两个合约面值不同时与Qty不同
+	Close   model.OrderResult     `json:"close"`
+	Open    model.OrderResult     `json:"open"`
+}
+
+// ErrRollLegFailed 平仓和开仓只有一边成功, 需要人工处理敞口
+var ErrRollLegFailed = errors.New("roll leg failed")
+
+// Roller 将即将到期的交割合约持仓移到下一期合约: 同一批次下平旧合约、开新合约两个订单
+//
+//	roller := delivery.NewRoller(futuresPrvApi, calendar, nil)
+//	results, err := roller.RollExpiring(model.OKX_FUTURES, 24*time.Hour, delivery.RollOptions{})
+type Roller struct {
+	api      goex.IFuturesPrvRest
+	calendar *Calendar
+	price    func(pair model.CurrencyPair) (float64, error)
+}
+
+// NewRoller price可为nil, 此时只能以市价移仓
+func NewRoller(api goex.IFuturesPrvRest, calendar *Calendar, price func(pair model.CurrencyPair) (float64, error)) *Roller {
+	return &Roller{api: api, calendar: calendar, price: price}
+}
+
+// RollExpiring 移仓所有在within时间内到期且有持仓的合约, 返回最后一个错误
+func (r *Roller) RollExpiring(venue string, within time.Duration, opts RollOptions) ([]RollResult, error) {
+	var (
+		now     = time.Now()
+		results []RollResult
+		lastErr error
+	)
+
+	for _, ct := range r.calendar.Expiring(venue, within, now) {
+		next, err := r.calendar.Next(venue, ct.Instrument, now)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		rs, err := r.Roll(ct.Instrument, next.Instrument, opts)
+		results = append(results, rs...)
+		if err != nil {
+			logger.Warnf("[delivery] roll %s -> %s error: %s", ct.Id, next.Id, err.Error())
+			lastErr = err
+		}
+	}
+
+	return results, lastErr
+}
+
+// Roll 按from的当前持仓移仓到to, 多空两个方向分别处理, 单向持仓按数量符号判断方向; 没有持仓时返回空
+func (r *Roller) Roll(from, to instrument.Instrument, opts RollOptions) ([]RollResult, error) {
+	positions, _, err := r.api.GetPositions(from.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results []RollResult
+		lastErr error
+	)
+
+	for _, pos := range positions {
+		if pos.Pair.Symbol != "" && pos.Pair.Symbol != from.Pair.Symbol {
+			continue
+		}
+
+		//okx买卖模式PosSide为空、binance单向持仓为BOTH, 按持仓数量的符号区分多空
+		signed := util.PositionQty(pos)
+		if signed == 0 {
+			continue
+		}
+		posSide := model.Futures_OpenBuy
+		if signed < 0 {
+			posSide = model.Futures_OpenSell
+		}
+
+		qty := math.Abs(pos.AvailQty)
+		if qty <= 0 {
+			qty = math.Abs(signed)
+		}
+
+		result, err := r.rollPosition(from, to, posSide, qty, pos.AvgPx, opts)
+		if result != nil {
+			results = append(results, *result)
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	return results, lastErr
+}
+
+func (r *Roller) rollPosition(from, to instrument.Instrument, posSide model.OrderSide, qty, avgPx float64, opts RollOptions) (*RollResult, error) {
+	var closeSide, openSide model.OrderSide
+	switch posSide {
+	case model.Futures_OpenBuy:
+		closeSide, openSide = model.Futures_CloseBuy, model.Futures_OpenBuy
+	case model.Futures_OpenSell:
+		closeSide, openSide = model.Futures_CloseSell, model.Futures_OpenSell
+	default:
+		return nil, fmt.Errorf("unknown position side: %s", posSide)
+	}
+
+	orderTy := opts.OrderTy
+	if orderTy == "" {
+		orderTy = model.OrderType_Market
+	}
+
+	var closePx, openPx float64
+	if orderTy != model.OrderType_Market {
+		var err error
+		if closePx, err = r.limitPrice(from.Pair, closeSide, opts.Slippage); err != nil {
+			return nil, err
+		}
+		if openPx, err = r.limitPrice(to.Pair, openSide, opts.Slippage); err != nil {
+			return nil, err
+		}
+	}
+
+	openQty, err := convertQty(from.Pair, to.Pair, qty, avgPx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RollResult{From: from, To: to, PosSide: posSide, Qty: qty, OpenQty: openQty}
+
+	reqs := []model.OrderRequest{
+		{Pair: from.Pair, Qty: qty, Price: closePx, Side: closeSide, OrderTy: orderTy, Opts: opts.Opts},
+		{Pair: to.Pair, Qty: openQty, Price: openPx, Side: openSide, OrderTy: orderTy, Opts: opts.Opts},
+	}
+
+	orderResults, _, err := r.api.CreateOrders(reqs)
+	if len(orderResults) > 0 {
+		result.Close = orderResults[0]
+	}
+	if len(orderResults) > 1 {
+		result.Open = orderResults[1]
+	}
+	if err != nil {
+		return result, err
+	}
+
+	closeOk := result.Close.Err == nil && result.Close.Order != nil
+	openOk := result.Open.Err == nil && result.Open.Order != nil
+	switch {
+	case closeOk && openOk:
+		return result, nil
+	case closeOk:
+		return result, fmt.Errorf("%w: open %s error: %v", ErrRollLegFailed, to.Id, result.Open.Err)
+	case openOk:
+		return result, fmt.Errorf("%w: close %s error: %v", ErrRollLegFailed, from.Id, result.Close.Err)
+	}
+
+	return result, fmt.Errorf("roll %s -> %s error: %v", from.Id, to.Id, result.Close.Err)
+}
+
+// limitPrice 买入向上、卖出向下偏移slippage, 提高成交概率
+func (r *Roller) limitPrice(pair model.CurrencyPair, side model.OrderSide, slippage float64) (float64, error) {
+	if r.price == nil {
+		return 0, errors.New("limit order roll need price")
+	}
+
+	px, err := r.price(pair)
+	if err != nil {
+		return 0, err
+	}
+
+	switch side {
+	case model.Futures_OpenBuy, model.Futures_CloseSell:
+		px *= 1 + slippage
+	default:
+		px *= 1 - slippage
+	}
+
+	return util.RoundToStep(px, pair.TickSize, util.RoundingMode_Nearest), nil
+}
+
+// convertQty 两个合约面值不同时按币数量换算张数, 按LotSize向下取整
+func convertQty(from, to model.CurrencyPair, qty, price float64) (float64, error) {
+	if from.ContractVal == to.ContractVal || from.ContractVal <= 0 || to.ContractVal <= 0 {
+		return qty, nil
+	}
+
+	contracts := util.RoundToStep(util.QtyToContracts(to, util.ContractsToQty(from, qty, price), price), to.LotSize, util.RoundingMode_Down)
+	if contracts <= 0 {
+		return 0, fmt.Errorf("%w: %v contracts of %s less than 1 lot of %s", util.ErrQtyTooSmall, qty, from.Symbol, to.Symbol)
+	}
+
+	return contracts, nil
+}
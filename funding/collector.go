@@ -0,0 +1,321 @@
+package funding
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/shadowors/goex/v2/instrument"
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/model"
+)
+
+// Collector 定时采集所有永续合约的当前及预测资金费率, 合约列表来自instrument.Registry
+//
+//	registry := instrument.DefaultRegistry.
+//		AddVenue(model.OKX_SWAP, goex.OKx.Swap).
+//		AddVenue(model.BINANCE_USDM, goex.Binance.Swap)
+//	registry.AddLoader(model.BINANCE_COINM, func() (map[string]model.CurrencyPair, error) {
+//		m, _, err := coinM.GetExchangeInfo()
+//		return m, err
+//	})
+//	collector := funding.NewCollector(registry, funding.NewFileStore("./funding")).
+//		AddSource(model.OKX_SWAP, goex.OKx.Swap).
+//		AddSource(model.BINANCE_USDM, goex.Binance.Swap).
+//		AddSource(model.BINANCE_COINM, coinM)
+//	collector.Start(5 * time.Minute)
+//	spreads := funding.Report(collector.Snapshots(), funding.ReportOptions{})
+type Collector struct {
+	registry *instrument.Registry
+	store    Store
+	gap      time.Duration
+
+	mu        sync.RWMutex
+	sources   map[string]Source
+	latest    map[string]map[string]Snapshot //venue -> symbol
+	intervals map[string]map[string]int64    //venue -> symbol -> 由历史推断的收取间隔(毫秒)
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewCollector store可为nil, 不保存历史
+func NewCollector(registry *instrument.Registry, store Store) *Collector {
+	return &Collector{
+		registry:  registry,
+		store:     store,
+		gap:       100 * time.Millisecond,
+		sources:   make(map[string]Source, 4),
+		latest:    make(map[string]map[string]Snapshot, 4),
+		intervals: make(map[string]map[string]int64, 4),
+	}
+}
+
+// AddSource venue需与instrument.Registry中的名称一致
+func (c *Collector) AddSource(venue string, src Source) *Collector {
+	c.mu.Lock()
+	c.sources[venue] = src
+	c.mu.Unlock()
+	return c
+}
+
+// WithRequestGap 同一交易所两次请求的间隔, 避免触发限频, 默认100ms
+func (c *Collector) WithRequestGap(gap time.Duration) *Collector {
+	c.gap = gap
+	return c
+}
+
+// Collect 采集一次所有交易所的永续合约资金费率, 各交易所并行采集, 返回最后一个错误
+func (c *Collector) Collect() error {
+	return c.eachVenue(c.collectVenue)
+}
+
+// Backfill 拉取每个永续合约最近limit期的历史资金费率写入Store, 同时推断收取间隔
+func (c *Collector) Backfill(limit int) error {
+	return c.eachVenue(func(venue string, src Source) error {
+		return c.backfillVenue(venue, src, limit)
+	})
+}
+
+func (c *Collector) eachVenue(fn func(venue string, src Source) error) error {
+	c.mu.RLock()
+	sources := make(map[string]Source, len(c.sources))
+	for venue, src := range c.sources {
+		sources[venue] = src
+	}
+	c.mu.RUnlock()
+
+	var (
+		wg      sync.WaitGroup
+		errMu   sync.Mutex
+		lastErr error
+	)
+
+	for venue, src := range sources {
+		wg.Add(1)
+		go func(venue string, src Source) {
+			defer wg.Done()
+			if err := fn(venue, src); err != nil {
+				logger.Warnf("[funding] %s error: %s", venue, err.Error())
+				errMu.Lock()
+				lastErr = fmt.Errorf("%s: %w", venue, err)
+				errMu.Unlock()
+			}
+		}(venue, src)
+	}
+
+	wg.Wait()
+
+	return lastErr
+}
+
+func (c *Collector) collectVenue(venue string, src Source) error {
+	insts := c.swaps(venue)
+	if len(insts) == 0 {
+		return errors.New("no perpetual instruments, load instrument registry first")
+	}
+
+	var (
+		now     = time.Now().UnixMilli()
+		snaps   = make([]Snapshot, 0, len(insts))
+		lastErr error
+	)
+
+	for i, inst := range insts {
+		if i > 0 {
+			time.Sleep(c.gap)
+		}
+
+		rate, _, err := src.GetFundingRate(inst.Pair)
+		if err != nil {
+			lastErr = fmt.Errorf("get %s funding rate: %w", inst.Pair.Symbol, err)
+			continue
+		}
+
+		snap := Snapshot{FundingRate: *rate, Venue: venue, Id: inst.Id, Ts: now}
+		snap.Symbol = inst.Pair.Symbol
+		snaps = append(snaps, snap)
+	}
+
+	c.fillMarkPx(venue, src, snaps)
+
+	c.mu.Lock()
+	latest := c.latest[venue]
+	if latest == nil {
+		latest = make(map[string]Snapshot, len(snaps))
+		c.latest[venue] = latest
+	}
+	for i := range snaps {
+		snaps[i].annualize(time.Duration(c.intervals[venue][snaps[i].Symbol]) * time.Millisecond)
+		latest[snaps[i].Symbol] = snaps[i]
+	}
+	c.mu.Unlock()
+
+	if c.store != nil {
+		if err := c.store.Append(snaps); err != nil {
+			return err
+		}
+	}
+
+	return lastErr
+}
+
+// fillMarkPx 没有标记价格时以最新价代替, 用于计算跨交易所价差
+func (c *Collector) fillMarkPx(venue string, src Source, snaps []Snapshot) {
+	tkSrc, ok := src.(tickersSource)
+	if !ok {
+		return
+	}
+
+	if !slices.ContainsFunc(snaps, func(s Snapshot) bool { return s.MarkPx <= 0 }) {
+		return
+	}
+
+	tickers, _, err := tkSrc.GetTickers("SWAP")
+	if err != nil {
+		logger.Warnf("[funding] %s get tickers error: %s", venue, err.Error())
+		return
+	}
+
+	for i := range snaps {
+		if snaps[i].MarkPx <= 0 {
+			snaps[i].MarkPx = tickers[snaps[i].Symbol].Last
+		}
+	}
+}
+
+func (c *Collector) backfillVenue(venue string, src Source, limit int) error {
+	var (
+		now     = time.Now().UnixMilli()
+		lastErr error
+	)
+
+	for i, inst := range c.swaps(venue) {
+		if i > 0 {
+			time.Sleep(c.gap)
+		}
+
+		rates, _, err := src.GetFundingRateHistory(inst.Pair, limit)
+		if err != nil {
+			lastErr = fmt.Errorf("get %s funding rate history: %w", inst.Pair.Symbol, err)
+			continue
+		}
+
+		slices.SortFunc(rates, func(a, b model.FundingRate) int {
+			return cmp.Compare(a.Tm, b.Tm)
+		})
+
+		interval := inferInterval(rates)
+
+		snaps := make([]Snapshot, 0, len(rates))
+		for _, rate := range rates {
+			snap := Snapshot{FundingRate: rate, Venue: venue, Id: inst.Id, Ts: now}
+			snap.Symbol = inst.Pair.Symbol
+			snap.annualize(time.Duration(interval) * time.Millisecond)
+			snaps = append(snaps, snap)
+		}
+
+		if interval > 0 {
+			c.mu.Lock()
+			if c.intervals[venue] == nil {
+				c.intervals[venue] = make(map[string]int64, 64)
+			}
+			c.intervals[venue][inst.Pair.Symbol] = interval
+			c.mu.Unlock()
+		}
+
+		if c.store != nil {
+			if err = c.store.Append(snaps); err != nil {
+				return err
+			}
+		}
+	}
+
+	return lastErr
+}
+
+// inferInterval 取相邻两期收取时间差的最小值, rates按时间升序
+func inferInterval(rates []model.FundingRate) int64 {
+	var interval int64
+	for i := 1; i < len(rates); i++ {
+		if d := rates[i].Tm - rates[i-1].Tm; d > 0 && (interval == 0 || d < interval) {
+			interval = d
+		}
+	}
+	return interval
+}
+
+func (c *Collector) swaps(venue string) []instrument.Instrument {
+	insts := c.registry.Instruments(venue)
+	return slices.DeleteFunc(insts, func(inst instrument.Instrument) bool {
+		return inst.Type != instrument.Type_Swap
+	})
+}
+
+// Latest 最近一次采集的资金费率
+func (c *Collector) Latest(venue, symbol string) (Snapshot, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snap, ok := c.latest[venue][symbol]
+	return snap, ok
+}
+
+// Snapshots 所有交易所最近一次采集的资金费率, 用于Report
+func (c *Collector) Snapshots() []Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var snaps []Snapshot
+	for _, m := range c.latest {
+		for _, snap := range m {
+			snaps = append(snaps, snap)
+		}
+	}
+	return snaps
+}
+
+// Start 立即采集一次, 之后后台按interval采集
+func (c *Collector) Start(interval time.Duration) error {
+	err := c.Collect()
+
+	c.mu.Lock()
+	if c.stopCh != nil {
+		c.mu.Unlock()
+		return err
+	}
+	c.stopCh = make(chan struct{})
+	stopCh := c.stopCh
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				_ = c.Collect()
+			}
+		}
+	}()
+
+	return err
+}
+
+// Stop 停止后台采集
+func (c *Collector) Stop() {
+	c.mu.Lock()
+	stopCh := c.stopCh
+	c.stopCh = nil
+	c.mu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		c.wg.Wait()
+	}
+}
@@ -0,0 +1,56 @@
+package funding
+
+import (
+	"time"
+
+	"github.com/shadowors/goex/v2/model"
+)
+
+// DefaultInterval 交易所不返回收取间隔且无法从历史推断时使用
+const DefaultInterval = 8 * time.Hour
+
+// Source 资金费率数据源, goex.IFuturesPubRest和binance/futures/dapi.DApi均已实现
+type Source interface {
+	GetFundingRate(pair model.CurrencyPair, opts ...model.OptionParameter) (rate *model.FundingRate, responseBody []byte, err error)
+	GetFundingRateHistory(pair model.CurrencyPair, limit int, opts ...model.OptionParameter) (rates []model.FundingRate, responseBody []byte, err error)
+}
+
+// tickersSource 数据源同时实现GetTickers时, 用最新价补充没有标记价格的资金费率
+type tickersSource interface {
+	GetTickers(instType string, opt ...model.OptionParameter) (tickers map[string]model.Ticker, responseBody []byte, err error)
+}
+
+// Snapshot 一个永续合约某一期的资金费率
+type Snapshot struct {
+	model.FundingRate
+
+	Venue   string  `json:"venue"`
+	Id      string  `json:"id"`       //统一Id, 见instrument.Instrument
+	Apr     float64 `json:"apr"`      //本期费率年化
+	NextApr float64 `json:"next_apr"` //预测费率年化
+	Ts      int64   `json:"ts"`       //采集时间(毫秒)
+}
+
+// Annualize 单期费率年化, interval<=0时按DefaultInterval
+func Annualize(rate float64, interval time.Duration) float64 {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return rate * float64(365*24*time.Hour) / float64(interval)
+}
+
+// Basis 标记价格相对指数价格的溢价率, 缺少价格时为0
+func (s Snapshot) Basis() float64 {
+	if s.MarkPx <= 0 || s.IndexPx <= 0 {
+		return 0
+	}
+	return (s.MarkPx - s.IndexPx) / s.IndexPx
+}
+
+func (s *Snapshot) annualize(interval time.Duration) {
+	if s.Interval > 0 {
+		interval = time.Duration(s.Interval) * time.Millisecond
+	}
+	s.Apr = Annualize(s.Rate, interval)
+	s.NextApr = Annualize(s.NextRate, interval)
+}
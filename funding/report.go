@@ -0,0 +1,101 @@
+package funding
+
+import (
+	"cmp"
+	"slices"
+)
+
+const (
+	defaultTakerFee = 0.0005
+	defaultHoldDays = 30
+)
+
+type ReportOptions struct {
+	TakerFees map[string]float64 //venue -> taker费率, 未配置的交易所按0.05%
+	HoldDays  float64            //预计持有天数, 手续费和价差按持有期摊销为年化, 默认30
+}
+
+// Spread 同一合约在两个交易所的资金费率价差: 在资金费率低的交易所做多, 高的交易所做空
+type Spread struct {
+	Id          string  `json:"id"`
+	LongVenue   string  `json:"long_venue"`
+	LongSymbol  string  `json:"long_symbol"`
+	ShortVenue  string  `json:"short_venue"`
+	ShortSymbol string  `json:"short_symbol"`
+	LongApr     float64 `json:"long_apr"`
+	ShortApr    float64 `json:"short_apr"`
+	FundingApr  float64 `json:"funding_apr"` //ShortApr-LongApr
+	LongBasis   float64 `json:"long_basis"`  //标记价格相对指数价格溢价率, 缺少价格时为0
+	ShortBasis  float64 `json:"short_basis"`
+	PriceSpread float64 `json:"price_spread"` //(空头价格-多头价格)/多头价格, 价格收敛时的收益
+	PriceApr    float64 `json:"price_apr"`    //PriceSpread按持有期年化
+	FeeApr      float64 `json:"fee_apr"`      //两边开平仓taker手续费按持有期年化
+	NetApr      float64 `json:"net_apr"`      //FundingApr+PriceApr-FeeApr
+}
+
+// Report 按统一Id匹配各交易所的资金费率, 生成所有交易所两两组合的价差, 按NetApr降序
+func Report(snaps []Snapshot, opts ReportOptions) []Spread {
+	if opts.HoldDays <= 0 {
+		opts.HoldDays = defaultHoldDays
+	}
+
+	byId := make(map[string][]Snapshot, len(snaps))
+	for _, snap := range snaps {
+		byId[snap.Id] = append(byId[snap.Id], snap)
+	}
+
+	var spreads []Spread
+	for id, group := range byId {
+		for i := 0; i < len(group); i++ {
+			for j := i + 1; j < len(group); j++ {
+				if group[i].Venue == group[j].Venue {
+					continue
+				}
+				long, short := group[i], group[j]
+				if long.Apr > short.Apr {
+					long, short = short, long
+				}
+				spreads = append(spreads, newSpread(id, long, short, opts))
+			}
+		}
+	}
+
+	slices.SortFunc(spreads, func(a, b Spread) int {
+		return cmp.Compare(b.NetApr, a.NetApr)
+	})
+
+	return spreads
+}
+
+func newSpread(id string, long, short Snapshot, opts ReportOptions) Spread {
+	sp := Spread{
+		Id:          id,
+		LongVenue:   long.Venue,
+		LongSymbol:  long.Symbol,
+		ShortVenue:  short.Venue,
+		ShortSymbol: short.Symbol,
+		LongApr:     long.Apr,
+		ShortApr:    short.Apr,
+		FundingApr:  short.Apr - long.Apr,
+		LongBasis:   long.Basis(),
+		ShortBasis:  short.Basis(),
+	}
+
+	if long.MarkPx > 0 && short.MarkPx > 0 {
+		sp.PriceSpread = (short.MarkPx - long.MarkPx) / long.MarkPx
+		sp.PriceApr = sp.PriceSpread * 365 / opts.HoldDays
+	}
+
+	fee := 2 * (takerFee(opts.TakerFees, long.Venue) + takerFee(opts.TakerFees, short.Venue))
+	sp.FeeApr = fee * 365 / opts.HoldDays
+	sp.NetApr = sp.FundingApr + sp.PriceApr - sp.FeeApr
+
+	return sp
+}
+
+func takerFee(fees map[string]float64, venue string) float64 {
+	if fee, ok := fees[venue]; ok {
+		return fee
+	}
+	return defaultTakerFee
+}
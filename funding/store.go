@@ -0,0 +1,127 @@
+package funding
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// Store 资金费率历史持久化
+type Store interface {
+	Append(snaps []Snapshot) error
+	// Load 按资金费收取时间升序, 同一合约同一期只保留最后写入的一条; since为0时不限制
+	Load(venue, symbol string, since int64) ([]Snapshot, error)
+}
+
+// FileStore 每个交易所市场一个json lines文件, 只追加写入
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) Append(snaps []Snapshot) error {
+	if len(snaps) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+
+	files := make(map[string]*os.File, 2)
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	for _, snap := range snaps {
+		f := files[snap.Venue]
+		if f == nil {
+			var err error
+			f, err = os.OpenFile(s.path(snap.Venue), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				return err
+			}
+			files[snap.Venue] = f
+		}
+
+		data, err := json.Marshal(snap)
+		if err != nil {
+			return err
+		}
+
+		if _, err = f.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *FileStore) Load(venue, symbol string, since int64) ([]Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path(venue))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		snaps   []Snapshot
+		indexOf = make(map[int64]int, 256) //Tm -> snaps下标
+		scanner = bufio.NewScanner(f)
+	)
+
+	scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+
+	for scanner.Scan() {
+		var snap Snapshot
+		if json.Unmarshal(scanner.Bytes(), &snap) != nil {
+			continue //忽略写入中断的行
+		}
+
+		if snap.Symbol != symbol || snap.Tm < since {
+			continue
+		}
+
+		if i, ok := indexOf[snap.Tm]; ok {
+			snaps[i] = snap
+			continue
+		}
+
+		indexOf[snap.Tm] = len(snaps)
+		snaps = append(snaps, snap)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(snaps, func(a, b Snapshot) int {
+		return cmp.Compare(a.Tm, b.Tm)
+	})
+
+	return snaps, nil
+}
+
+func (s *FileStore) path(venue string) string {
+	return filepath.Join(s.dir, venue+".jsonl")
+}
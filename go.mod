@@ -3,11 +3,14 @@ module github.com/shadowors/goex/v2
 go 1.23.0
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/buger/jsonparser v1.1.1
 	github.com/google/uuid v1.3.1
 	github.com/nntaoli/go-tools v0.0.0-20231117134637-ffc092526634
 	github.com/spf13/cast v1.5.0
 	github.com/valyala/fasthttp v1.47.0
+	golang.org/x/net v0.37.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -16,6 +19,6 @@ require (
 	github.com/klauspost/compress v1.16.5 // indirect
 	github.com/rogpeppe/go-internal v1.8.0 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
-	golang.org/x/net v0.37.0 // indirect
 	golang.org/x/text v0.23.0 // indirect
+	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 )
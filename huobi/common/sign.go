@@ -3,28 +3,35 @@ package common
 import (
 	"fmt"
 	"github.com/shadowors/goex/v2/options"
-	"github.com/shadowors/goex/v2/util"
+	"github.com/shadowors/goex/v2/signer"
 	"net/url"
-	"time"
 )
 
-func DoSignParam(httpMethod, reqUrl string, apiOpt options.ApiOptions) *url.Values {
+// DoSignParam 默认使用Secret做HMAC-SHA256签名, 设置了ApiOptions.Signer时使用自定义签名
+func DoSignParam(httpMethod, reqUrl string, apiOpt options.ApiOptions) (*url.Values, error) {
 	///////////////////// 参数签名 ////////////////////////
 	signParams := url.Values{}
 	signParams.Set("AccessKeyId", apiOpt.Key)
 	signParams.Set("SignatureMethod", "HmacSHA256")
 	signParams.Set("SignatureVersion", "2")
-	signParams.Set("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05"))
+	signParams.Set("Timestamp", apiOpt.Now().UTC().Format("2006-01-02T15:04:05"))
 
 	reqURL, _ := url.Parse(reqUrl)
 	path := reqURL.RequestURI()
 	domain := reqURL.Hostname()
 
 	payload := fmt.Sprintf("%s\n%s\n%s\n%s", httpMethod, domain, path, signParams.Encode())
-	sign, _ := util.HmacSHA256Base64Sign(apiOpt.Secret, payload)
+	s := apiOpt.Signer
+	if s == nil {
+		s = signer.NewHmacSHA256Base64(apiOpt.Secret)
+	}
+	sign, err := s.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
 
 	signParams.Set("Signature", sign)
 	///////////////////签名结束////////////////////
 
-	return &signParams
+	return &signParams, nil
 }
@@ -1,7 +1,11 @@
 package futures
 
 import (
+	"fmt"
 	. "github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/util"
+	"net/url"
+	"strings"
 )
 
 func AdaptSideToDirectionAndOffset(side OrderSide) (direction, offset string) {
@@ -48,6 +52,41 @@ func AdaptKlinePeriod(period KlinePeriod) string {
 	}
 }
 
+// AdaptAlgoOrderStatus 计划委托/止盈止损订单状态
+func AdaptAlgoOrderStatus(s int) OrderStatus {
+	switch s {
+	case 1, 2, 3:
+		return OrderStatus_Pending
+	case 4:
+		return OrderStatus_Finished
+	case 5:
+		return OrderStatus_Rejected
+	case 6:
+		return OrderStatus_Canceled
+	case 11:
+		return OrderStatus_Canceling
+	}
+	return 1000
+}
+
+// AdaptAlgoOrderPriceType 条件单触发后的委托类型, 市价使用最优5档
+func AdaptAlgoOrderPriceType(ty OrderType) string {
+	switch ty {
+	case OrderType_StopLimit, OrderType_TakeProfitLimit:
+		return "limit"
+	}
+	return "optimal_5"
+}
+
+// AdaptTriggerType 止损: 买入向上触发, 卖出向下触发; 止盈相反
+func AdaptTriggerType(ty OrderType, direction string) string {
+	stop := ty == OrderType_StopMarket || ty == OrderType_StopLimit
+	if stop == (direction == "buy") {
+		return "ge"
+	}
+	return "le"
+}
+
 func AdaptStatus(s int) OrderStatus {
 	switch s {
 	case 1, 2, 3:
@@ -63,3 +102,99 @@ func AdaptStatus(s int) OrderStatus {
 	}
 	return 1000
 }
+
+// AdaptOrderOptionParameters 转换类型化的下单参数, 有效方式/只做maker/对手价通过order_price_type实现
+func AdaptOrderOptionParameters(params *url.Values) error {
+	opts, err := util.PopOrderOptions(params)
+	if err != nil {
+		return err
+	}
+
+	if opts.PriceMatch != "" {
+		priceType, ok := adaptPriceMatch(opts.PriceMatch)
+		if !ok {
+			return util.UnsupportedOption("huobi", "price match %s", opts.PriceMatch)
+		}
+		params.Set("order_price_type", priceType)
+		params.Del("price")
+	}
+
+	priceType := params.Get("order_price_type")
+	if opts.PostOnly {
+		if priceType != "limit" {
+			return util.UnsupportedOption("huobi", "post only with order price type %s", priceType)
+		}
+		params.Set("order_price_type", "post_only")
+	} else if opts.TimeInForce != "" {
+		tif := strings.ToLower(string(opts.TimeInForce))
+		switch priceType {
+		case "limit":
+			params.Set("order_price_type", tif)
+		case "opponent", "optimal_5", "optimal_10", "optimal_20":
+			params.Set("order_price_type", priceType+"_"+tif)
+		default:
+			return util.UnsupportedOption("huobi", "time in force %s with order price type %s", opts.TimeInForce, priceType)
+		}
+	}
+
+	if opts.ReduceOnly {
+		params.Set("reduce_only", "1")
+	}
+
+	if opts.ClosePosition {
+		return util.UnsupportedOption("huobi", "close position order")
+	}
+
+	if opts.STPMode != "" {
+		return util.UnsupportedOption("huobi", "self trade prevention")
+	}
+
+	return nil
+}
+
+// adaptAlgoOrderOptionParameters 计划委托只支持只减仓
+func adaptAlgoOrderOptionParameters(params *url.Values, ty OrderType) error {
+	opts, err := util.PopOrderOptions(params)
+	if err != nil {
+		return err
+	}
+
+	if opts.PostOnly || opts.TimeInForce != "" || opts.ClosePosition || opts.STPMode != "" || opts.PriceMatch != "" {
+		return util.UnsupportedOption("huobi", "algo order only support reduce only")
+	}
+
+	if opts.ReduceOnly {
+		if ty == OrderType_OCO {
+			return util.UnsupportedOption("huobi", "reduce only with tpsl order")
+		}
+		params.Set("reduce_only", "1")
+	}
+
+	return nil
+}
+
+// adaptPriceMatch 对手价N档对应最优N档
+func adaptPriceMatch(pm PriceMatch) (string, bool) {
+	switch pm {
+	case PriceMatch_Opponent:
+		return "opponent", true
+	case PriceMatch_Opponent5:
+		return "optimal_5", true
+	case PriceMatch_Opponent10:
+		return "optimal_10", true
+	case PriceMatch_Opponent20:
+		return "optimal_20", true
+	}
+	return "", false
+}
+
+// adaptAccountType 只支持现货账户与U本位永续合约账户之间划转
+func adaptAccountType(ty AccountType) (string, error) {
+	switch ty {
+	case AccountType_Trading, AccountType_Spot:
+		return "spot", nil
+	case AccountType_UsdtFutures:
+		return "linear-swap", nil
+	}
+	return "", fmt.Errorf("%s unsupported account type: %s", HUOBI, ty)
+}
@@ -0,0 +1,27 @@
+package futures
+
+import (
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/options"
+)
+
+// WithEnvironment 火币无测试网, 不支持的环境清空地址, 避免误连实盘
+func WithEnvironment(env options.Environment) options.UriOption {
+	return func(c *options.UriOptions) {
+		switch env {
+		case options.Env_Production:
+			c.Endpoint = "https://api.hbdm.com"
+			c.WsEndpoint = "wss://api.hbdm.com/linear-swap-ws"
+			c.WsPrivateEndpoint = "wss://api.hbdm.com/linear-swap-notification"
+			c.SpotEndpoint = "https://api.huobi.pro"
+		case options.Env_AWS:
+			c.Endpoint = "https://api.hbdm.vn"
+			c.WsEndpoint = "wss://api.hbdm.vn/linear-swap-ws"
+			c.WsPrivateEndpoint = "wss://api.hbdm.vn/linear-swap-notification"
+			c.SpotEndpoint = "https://api-aws.huobi.pro"
+		default:
+			logger.Errorf("[huobi futures] unsupported environment: %s", env)
+			c.Endpoint, c.WsEndpoint, c.WsPrivateEndpoint, c.SpotEndpoint = "", "", "", ""
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package futures
 
 import (
+	. "github.com/shadowors/goex/v2/model"
 	. "github.com/shadowors/goex/v2/options"
 )
 
@@ -11,6 +12,7 @@ type Futures struct {
 type USDTSwap struct {
 	uriOpts         UriOptions
 	unmarshalerOpts UnmarshalerOptions
+	currencyPairM   map[string]CurrencyPair
 }
 
 func New() *Futures {
@@ -22,26 +24,71 @@ func New() *Futures {
 func NewUSDTSwap() *USDTSwap {
 	f := &USDTSwap{
 		uriOpts: UriOptions{
-			Endpoint:            "https://api.hbdm.com",
-			TickerUri:           "/linear-swap-ex/market/detail/merged",
-			DepthUri:            "/linear-swap-ex/market/depth",
-			KlineUri:            "/linear-swap-ex/market/history/kline",
-			GetOrderUri:         "/linear-swap-api/v1/swap_cross_order_info",
-			GetPendingOrdersUri: "/linear-swap-api/v1/swap_cross_openorders",
-			GetHistoryOrdersUri: "/linear-swap-api/v3/swap_cross_hisorders",
-			CancelOrderUri:      "/linear-swap-api/v1/swap_cross_cancel",
-			NewOrderUri:         "/linear-swap-api/v1/swap_cross_order",
+			Endpoint:                 "https://api.hbdm.com",
+			WsEndpoint:               "wss://api.hbdm.com/linear-swap-ws",
+			WsPrivateEndpoint:        "wss://api.hbdm.com/linear-swap-notification",
+			SpotEndpoint:             "https://api.huobi.pro", //划转接口只在现货域名下
+			TickerUri:                "/linear-swap-ex/market/detail/merged",
+			DepthUri:                 "/linear-swap-ex/market/depth",
+			KlineUri:                 "/linear-swap-ex/market/history/kline",
+			GetTradesUri:             "/linear-swap-ex/market/history/trade",
+			GetTickersUri:            "/linear-swap-ex/market/detail/batch_merged",
+			GetServerTimeUri:         "/api/v1/timestamp",
+			GetOrderUri:              "/linear-swap-api/v1/swap_cross_order_info",
+			GetPendingOrdersUri:      "/linear-swap-api/v1/swap_cross_openorders",
+			GetHistoryOrdersUri:      "/linear-swap-api/v3/swap_cross_hisorders",
+			CancelOrderUri:           "/linear-swap-api/v1/swap_cross_cancel",
+			NewOrderUri:              "/linear-swap-api/v1/swap_cross_order",
+			GetExchangeInfoUri:       "/linear-swap-api/v1/swap_contract_info",
+			GetAccountUri:            "/linear-swap-api/v1/swap_cross_account_info",
+			GetPositionsUri:          "/linear-swap-api/v1/swap_cross_position_info",
+			NewOrdersUri:             "/linear-swap-api/v1/swap_cross_batchorder",
+			CancelOrdersUri:          "/linear-swap-api/v1/swap_cross_cancel",
+			CancelAllOrdersUri:       "/linear-swap-api/v1/swap_cross_cancelall",
+			SetLeverageUri:           "/linear-swap-api/v1/swap_cross_switch_lever_rate",
+			GetLeverageUri:           "/linear-swap-api/v1/swap_cross_account_info",
+			SetPositionModeUri:       "/linear-swap-api/v1/swap_cross_switch_position_mode",
+			NewAlgoOrderUri:          "/linear-swap-api/v1/swap_cross_trigger_order",
+			GetAlgoOrderUri:          "/linear-swap-api/v1/swap_cross_trigger_hisorders",
+			GetPendingAlgoOrdersUri:  "/linear-swap-api/v1/swap_cross_trigger_openorders",
+			CancelAlgoOrderUri:       "/linear-swap-api/v1/swap_cross_trigger_cancel",
+			NewTpslOrderUri:          "/linear-swap-api/v1/swap_cross_tpsl_order",
+			GetPendingTpslOrdersUri:  "/linear-swap-api/v1/swap_cross_tpsl_openorders",
+			GetHistoryTpslOrdersUri:  "/linear-swap-api/v1/swap_cross_tpsl_hisorders",
+			CancelTpslOrderUri:       "/linear-swap-api/v1/swap_cross_tpsl_cancel",
+			GetFillsUri:              "/linear-swap-api/v3/swap_cross_matchresults",
+			GetFundingRateUri:        "/linear-swap-api/v1/swap_funding_rate",
+			GetFundingRateHistoryUri: "/linear-swap-api/v1/swap_historical_funding_rate",
+			TransferUri:              "/v2/account/transfer",
 		},
 		unmarshalerOpts: UnmarshalerOptions{
-			ResponseUnmarshaler:                 UnmarshalResponse,
-			KlineUnmarshaler:                    UnmarshalKline,
-			TickerUnmarshaler:                   UnmarshalTicker,
-			CancelOrderResponseUnmarshaler:      UnmarshalCancelOrderResponse,
-			CreateOrderResponseUnmarshaler:      UnmarshalCreateOrderResponse,
-			GetOrderInfoResponseUnmarshaler:     UnmarshalGetOrderInfoResponse,
-			GetPendingOrdersResponseUnmarshaler: UnmarshalGetPendingOrdersResponse,
-			GetHistoryOrdersResponseUnmarshaler: UnmarshalGetHistoryOrdersResponse,
+			ResponseUnmarshaler:                      UnmarshalResponse,
+			KlineUnmarshaler:                         UnmarshalKline,
+			TickerUnmarshaler:                        UnmarshalTicker,
+			GetTradesResponseUnmarshaler:             UnmarshalGetTradesResponse,
+			GetTickersResponseUnmarshaler:            UnmarshalGetTickersResponse,
+			GetServerTimeResponseUnmarshaler:         UnmarshalGetServerTimeResponse,
+			CancelOrderResponseUnmarshaler:           UnmarshalCancelOrderResponse,
+			CreateOrderResponseUnmarshaler:           UnmarshalCreateOrderResponse,
+			GetOrderInfoResponseUnmarshaler:          UnmarshalGetOrderInfoResponse,
+			GetPendingOrdersResponseUnmarshaler:      UnmarshalGetPendingOrdersResponse,
+			GetHistoryOrdersResponseUnmarshaler:      UnmarshalGetHistoryOrdersResponse,
+			GetExchangeInfoResponseUnmarshaler:       UnmarshalGetExchangeInfoResponse,
+			GetFuturesAccountResponseUnmarshaler:     UnmarshalGetFuturesAccountResponse,
+			GetPositionsResponseUnmarshaler:          UnmarshalGetPositionsResponse,
+			CreateOrdersResponseUnmarshaler:          UnmarshalCreateOrdersResponse,
+			CancelOrdersResponseUnmarshaler:          UnmarshalCancelOrdersResponse,
+			CancelAllOrdersResponseUnmarshaler:       UnmarshalCancelOrderResponse,
+			GetLeverageResponseUnmarshaler:           UnmarshalGetLeverageResponse,
+			CreateAlgoOrderResponseUnmarshaler:       UnmarshalCreateAlgoOrderResponse,
+			GetPendingAlgoOrdersResponseUnmarshaler:  UnmarshalGetPendingAlgoOrdersResponse,
+			CancelAlgoOrderResponseUnmarshaler:       UnmarshalCancelOrderResponse,
+			GetFillsResponseUnmarshaler:              UnmarshalGetFillsResponse,
+			GetFundingRateResponseUnmarshaler:        UnmarshalGetFundingRateResponse,
+			GetFundingRateHistoryResponseUnmarshaler: UnmarshalGetFundingRateHistoryResponse,
+			TransferResponseUnmarshaler:              UnmarshalTransferResponse,
 		},
+		currencyPairM: make(map[string]CurrencyPair, 64),
 	}
 	return f
 }
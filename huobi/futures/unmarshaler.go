@@ -3,9 +3,12 @@ package futures
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/buger/jsonparser"
 	. "github.com/shadowors/goex/v2/model"
+	. "github.com/shadowors/goex/v2/util"
 	"github.com/spf13/cast"
+	"strings"
 )
 
 func UnmarshalResponse(data []byte, i interface{}) error {
@@ -42,6 +45,10 @@ func UnmarshalKline(data []byte) ([]Kline, error) {
 	return lines, err
 }
 
+func UnmarshalGetServerTimeResponse(data []byte) (int64, error) {
+	return jsonparser.GetInt(data, "ts")
+}
+
 func UnmarshalTicker(data []byte) (*Ticker, error) {
 	tk := &Ticker{}
 
@@ -50,8 +57,34 @@ func UnmarshalTicker(data []byte) (*Ticker, error) {
 		return nil, err
 	}
 
-	jsonparser.ObjectEach(tkData, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+	unmarshalTicker(tkData, tk)
+
+	return tk, nil
+}
+
+// UnmarshalGetTickersResponse key为contract_code
+func UnmarshalGetTickersResponse(data []byte) (map[string]Ticker, error) {
+	var tickers = make(map[string]Ticker, 256)
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var tk Ticker
+		if unmarshalTicker(value, &tk) != nil {
+			return
+		}
+		tickers[tk.Pair.Symbol] = tk
+	}, "ticks")
+
+	return tickers, err
+}
+
+// unmarshalTicker vol为合约张数, trade_turnover为计价币成交额
+func unmarshalTicker(data []byte, tk *Ticker) error {
+	return jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
 		switch string(key) {
+		case "contract_code":
+			tk.Pair.Symbol = string(value)
+		case "trade_turnover":
+			tk.QuoteVol = cast.ToFloat64(string(value))
 		case "vol":
 			tk.Vol = cast.ToFloat64(string(value))
 		case "high":
@@ -68,19 +101,21 @@ func UnmarshalTicker(data []byte) (*Ticker, error) {
 			if err != nil {
 				return err
 			}
-			tk.Buy = bids[0]
+			if len(bids) == 2 {
+				tk.Buy, tk.BuyQty = bids[0], bids[1]
+			}
 		case "ask":
 			var asks []float64
 			err := UnmarshalResponse(value, &asks)
 			if err != nil {
 				return err
 			}
-			tk.Sell = asks[0]
+			if len(asks) == 2 {
+				tk.Sell, tk.SellQty = asks[0], asks[1]
+			}
 		}
 		return nil
 	})
-
-	return tk, nil
 }
 
 func UnmarshalCreateOrderResponse(data []byte) (*Order, error) {
@@ -108,6 +143,68 @@ func UnmarshalCancelOrderResponse(data []byte) error {
 	return nil
 }
 
+// UnmarshalCreateOrdersResponse 批量下单返回, 按index(从1开始)还原请求顺序
+func UnmarshalCreateOrdersResponse(data []byte) ([]OrderResult, error) {
+	var results []OrderResult
+	at := func(index int64) *OrderResult {
+		for int64(len(results)) < index {
+			results = append(results, OrderResult{})
+		}
+		return &results[index-1]
+	}
+
+	errorsData, _, _, _ := jsonparser.Get(data, "errors")
+	if len(errorsData) > 0 {
+		_, err := jsonparser.ArrayEach(errorsData, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+			index, _ := jsonparser.GetInt(value, "index")
+			if index <= 0 {
+				return
+			}
+			errCode, _ := jsonparser.GetInt(value, "err_code")
+			errMsg, _ := jsonparser.GetString(value, "err_msg")
+			at(index).Err = fmt.Errorf("%d: %s", errCode, errMsg)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	successData, _, _, _ := jsonparser.Get(data, "success")
+	if len(successData) > 0 {
+		_, err := jsonparser.ArrayEach(successData, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+			index, _ := jsonparser.GetInt(value, "index")
+			if index <= 0 {
+				return
+			}
+			ret := at(index)
+			ret.Id, _ = jsonparser.GetString(value, "order_id_str")
+			clientOrderId, _, _, _ := jsonparser.Get(value, "client_order_id")
+			ret.CId = string(clientOrderId)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// UnmarshalCancelOrdersResponse 批量撤单返回, 只包含失败的订单
+func UnmarshalCancelOrdersResponse(data []byte) ([]OrderResult, error) {
+	var results []OrderResult
+	errorsData, _, _, _ := jsonparser.Get(data, "errors")
+	if len(errorsData) == 0 {
+		return nil, nil
+	}
+	_, err := jsonparser.ArrayEach(errorsData, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		orderId, _ := jsonparser.GetString(value, "order_id")
+		errCode, _ := jsonparser.GetInt(value, "err_code")
+		errMsg, _ := jsonparser.GetString(value, "err_msg")
+		results = append(results, OrderResult{Id: orderId, Err: fmt.Errorf("%d: %s", errCode, errMsg)})
+	})
+	return results, err
+}
+
 func UnmarshalGetOrderInfoResponse(data []byte) (*Order, error) {
 	var (
 		order *Order
@@ -171,6 +268,8 @@ func unmarshalOrderResponse(data []byte) (*Order, error) {
 			direction = string(value)
 		case "offset":
 			orderOffset = string(value)
+		case "order_price_type":
+			order.OrderTy = OrderType(value)
 		}
 		return nil
 	})
@@ -190,12 +289,392 @@ func UnmarshalGetHistoryOrdersResponse(data []byte) ([]Order, error) {
 		orders []Order
 	)
 	_, err = jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
-		ord, err := unmarshalOrderResponse(value)
+		ord, er := unmarshalOrderResponse(value)
+		if er != nil {
+			err = er
+			return
+		}
+		orders = append(orders, *ord)
+	})
+	return orders, err
+}
+
+func UnmarshalGetFillsResponse(data []byte) ([]Trade, error) {
+	var fills []Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			fill                   Trade
+			orderOffset, direction string
+		)
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "contract_code":
+				fill.Pair.Symbol = valStr
+			case "match_id":
+				fill.Id = valStr
+			case "order_id_str":
+				fill.OrderId = valStr
+			case "trade_price":
+				fill.Price = cast.ToFloat64(valStr)
+			case "trade_volume":
+				fill.Qty = cast.ToFloat64(valStr)
+			case "trade_fee":
+				fill.Fee = -cast.ToFloat64(valStr) //火币手续费为负表示扣除
+			case "fee_asset":
+				fill.FeeCcy = valStr
+			case "role":
+				fill.IsMaker = strings.EqualFold(valStr, "maker")
+			case "create_date", "created_at":
+				fill.Ts = cast.ToInt64(valStr)
+			case "direction":
+				direction = valStr
+			case "offset":
+				orderOffset = valStr
+			}
+			return nil
+		})
+		fill.Side = AdaptOffsetDirectionToOrderSide(orderOffset, direction)
+		fills = append(fills, fill)
+	})
+	return fills, err
+}
+
+// UnmarshalGetTradesResponse 按批次返回, 每批包含多笔成交, amount为合约张数
+func UnmarshalGetTradesResponse(data []byte) ([]Trade, error) {
+	var trades []Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		_, err = jsonparser.ArrayEach(value, func(tradeData []byte, dataType jsonparser.ValueType, offset int, err error) {
+			var trade Trade
+			err = jsonparser.ObjectEach(tradeData, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+				valStr := string(val)
+				switch string(key) {
+				case "id":
+					trade.Id = valStr
+				case "price":
+					trade.Price = cast.ToFloat64(valStr)
+				case "amount":
+					trade.Qty = cast.ToFloat64(valStr)
+				case "ts":
+					trade.Ts = cast.ToInt64(valStr)
+				case "direction":
+					trade.Side = OrderSide(valStr)
+				}
+				return nil
+			})
+			trades = append(trades, trade)
+		}, "data")
+	}, "data")
+	return trades, err
+}
+
+// UnmarshalGetFundingRateResponse estimated_rate为预测费率, 可能为null
+func UnmarshalGetFundingRateResponse(data []byte) (*FundingRate, error) {
+	rateData, _, _, err := jsonparser.Get(data, "data")
+	if err != nil {
+		return nil, err
+	}
+
+	var rate FundingRate
+	err = unmarshalFundingRate(rateData, &rate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
+func UnmarshalGetFundingRateHistoryResponse(data []byte) ([]FundingRate, error) {
+	var rates []FundingRate
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var rate FundingRate
+		if unmarshalFundingRate(value, &rate) == nil {
+			rates = append(rates, rate)
+		}
+	}, "data", "data")
+	return rates, err
+}
+
+func unmarshalFundingRate(data []byte, rate *FundingRate) error {
+	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		valStr := string(value)
+		switch string(key) {
+		case "contract_code":
+			rate.Symbol = valStr
+		case "funding_rate":
+			rate.Rate = cast.ToFloat64(valStr)
+		case "funding_time":
+			rate.Tm = cast.ToInt64(valStr)
+		case "estimated_rate":
+			if dataType != jsonparser.Null {
+				rate.NextRate = cast.ToFloat64(valStr)
+			}
+		case "next_funding_time":
+			if dataType != jsonparser.Null {
+				rate.NextTm = cast.ToInt64(valStr)
+			}
+		}
+		return nil
+	})
+	if rate.NextTm > rate.Tm && rate.Tm > 0 {
+		rate.Interval = rate.NextTm - rate.Tm
+	}
+	return err
+}
+
+func UnmarshalGetExchangeInfoResponse(data []byte) (map[string]CurrencyPair, error) {
+	var currencyPairM = make(map[string]CurrencyPair, 64)
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			currencyPair CurrencyPair
+			contractType string
+		)
+
+		currencyPair.LotSize = 1 //下单数量单位为张
+		currencyPair.MinQty = 1
+
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "contract_code":
+				currencyPair.Symbol = valStr
+			case "symbol":
+				currencyPair.BaseSymbol = valStr
+				currencyPair.ContractValCurrency = valStr
+			case "trade_partition":
+				currencyPair.QuoteSymbol = valStr
+				currencyPair.SettlementCurrency = valStr
+			case "contract_size":
+				currencyPair.ContractVal = cast.ToFloat64(valStr)
+			case "price_tick":
+				currencyPair.TickSize = cast.ToFloat64(valStr)
+				currencyPair.PricePrecision = PrecisionOf(valStr)
+			case "contract_type":
+				contractType = valStr
+			case "delivery_time":
+				currencyPair.ContractDeliveryDate = cast.ToInt64(valStr)
+			}
+			return nil
+		})
+
+		k := currencyPair.BaseSymbol + currencyPair.QuoteSymbol
+		if contractType != "" && contractType != "swap" {
+			currencyPair.ContractAlias = contractType
+			k += contractType
+		}
+		currencyPairM[k] = currencyPair
+	}, "data")
+
+	return currencyPairM, err
+}
+
+func UnmarshalGetFuturesAccountResponse(data []byte) (map[string]FuturesAccount, error) {
+	var accounts = make(map[string]FuturesAccount, 2)
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var acc FuturesAccount
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "margin_account":
+				acc.Coin = valStr
+			case "margin_balance":
+				acc.Eq = cast.ToFloat64(valStr)
+			case "withdraw_available":
+				acc.AvailEq = cast.ToFloat64(valStr)
+			case "margin_frozen":
+				acc.FrozenBal = cast.ToFloat64(valStr)
+			case "profit_unreal":
+				acc.Upl = cast.ToFloat64(valStr)
+			case "risk_rate":
+				acc.RiskRate = cast.ToFloat64(valStr)
+			}
+			return nil
+		})
+		accounts[acc.Coin] = acc
+	})
+	return accounts, err
+}
+
+func UnmarshalGetPositionsResponse(data []byte) ([]FuturesPosition, error) {
+	var positions []FuturesPosition
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var pos FuturesPosition
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "contract_code":
+				pos.Pair.Symbol = valStr
+			case "volume":
+				pos.Qty = cast.ToFloat64(valStr)
+			case "available":
+				pos.AvailQty = cast.ToFloat64(valStr)
+			case "cost_hold":
+				pos.AvgPx = cast.ToFloat64(valStr)
+			case "profit_unreal":
+				pos.Upl = cast.ToFloat64(valStr)
+			case "lever_rate":
+				pos.Lever = cast.ToFloat64(valStr)
+			case "position_margin":
+				pos.Margin = cast.ToFloat64(valStr)
+			case "direction":
+				if valStr == "sell" {
+					pos.PosSide = Futures_OpenSell
+				} else {
+					pos.PosSide = Futures_OpenBuy
+				}
+			}
+			return nil
+		})
+		positions = append(positions, pos)
+	})
+	return positions, err
+}
+
+// UnmarshalGetLeverageResponse 请求时传入contract_code, 取contract_detail中的lever_rate
+func UnmarshalGetLeverageResponse(data []byte) (float64, error) {
+	var (
+		lever float64
+		found bool
+	)
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		_, _ = jsonparser.ArrayEach(value, func(detail []byte, dataType jsonparser.ValueType, offset int, err error) {
+			if found {
+				return
+			}
+			if v, err := jsonparser.GetUnsafeString(detail, "lever_rate"); err == nil {
+				lever = cast.ToFloat64(v)
+				found = true
+			}
+		}, "contract_detail")
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, errors.New("lever_rate not found")
+	}
+	return lever, nil
+}
+
+// UnmarshalCreateAlgoOrderResponse 止盈止损单返回tp_order和sl_order, 订单ID用逗号连接
+func UnmarshalCreateAlgoOrderResponse(data []byte) (*Order, error) {
+	var ids []string
+	for _, leg := range []string{"tp_order", "sl_order"} {
+		if id, err := jsonparser.GetString(data, leg, "order_id_str"); err == nil && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > 0 {
+		return &Order{Id: strings.Join(ids, ",")}, nil
+	}
+	return UnmarshalCreateOrderResponse(data)
+}
+
+// UnmarshalGetPendingAlgoOrdersResponse 计划委托/止盈止损的当前委托和历史委托, 止盈止损单按单腿返回
+func UnmarshalGetPendingAlgoOrdersResponse(data []byte) ([]Order, error) {
+	var orders []Order
+
+	ordersData, _, _, err := jsonparser.Get(data, "orders")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = jsonparser.ArrayEach(ordersData, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		ord, err := unmarshalAlgoOrderResponse(value)
 		if err != nil {
-			err = err
 			return
 		}
 		orders = append(orders, *ord)
 	})
+
 	return orders, err
 }
+
+func unmarshalAlgoOrderResponse(data []byte) (*Order, error) {
+	var (
+		order                                 = new(Order)
+		orderOffset, direction                string
+		priceType, triggerType, tpslOrderType string
+		updateTime                            int64
+	)
+
+	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		valStr := string(value)
+		switch string(key) {
+		case "order_id_str":
+			order.Id = valStr
+		case "contract_code":
+			order.Pair.Symbol = valStr
+		case "volume":
+			order.Qty = cast.ToFloat64(valStr)
+		case "order_price":
+			order.Price = cast.ToFloat64(valStr)
+		case "trigger_price":
+			order.TriggerPx = cast.ToFloat64(valStr)
+		case "status":
+			order.Status = AdaptAlgoOrderStatus(cast.ToInt(valStr))
+		case "created_at":
+			order.CreatedAt = cast.ToInt64(valStr)
+		case "update_time":
+			updateTime = cast.ToInt64(valStr)
+		case "direction":
+			direction = valStr
+		case "offset":
+			orderOffset = valStr
+		case "order_price_type":
+			priceType = valStr
+		case "trigger_type":
+			triggerType = valStr
+		case "tpsl_order_type":
+			tpslOrderType = valStr
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	limit := priceType == "limit"
+	if !limit {
+		order.Price = 0
+	}
+
+	var stop bool
+	if tpslOrderType != "" { //止盈止损只能平仓
+		order.Side = AdaptOffsetDirectionToOrderSide("close", direction)
+		stop = tpslOrderType == "sl"
+	} else {
+		order.Side = AdaptOffsetDirectionToOrderSide(orderOffset, direction)
+		stop = (triggerType == "ge") == (direction == "buy")
+	}
+
+	switch {
+	case stop && limit:
+		order.OrderTy = OrderType_StopLimit
+	case stop:
+		order.OrderTy = OrderType_StopMarket
+	case limit:
+		order.OrderTy = OrderType_TakeProfitLimit
+	default:
+		order.OrderTy = OrderType_TakeProfitMarket
+	}
+
+	switch order.Status {
+	case OrderStatus_Finished:
+		order.FinishedAt = updateTime
+	case OrderStatus_Canceled:
+		order.CanceledAt = updateTime
+	}
+
+	return order, nil
+}
+
+// UnmarshalTransferResponse data为划转ID, 划转同步完成
+func UnmarshalTransferResponse(data []byte) (*Transfer, error) {
+	id := strings.Trim(string(data), "\"")
+	if id == "" || id == "null" {
+		return nil, errors.New("empty transfer id")
+	}
+	return &Transfer{Id: id, Status: TransferStatus_Success}, nil
+}
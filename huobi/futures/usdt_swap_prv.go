@@ -11,8 +11,12 @@ import (
 	. "github.com/shadowors/goex/v2/model"
 	"github.com/shadowors/goex/v2/options"
 	. "github.com/shadowors/goex/v2/util"
+	"github.com/shadowors/goex/v2/validator"
+	"math"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 )
 
 type BaseResponse struct {
@@ -28,13 +32,26 @@ type TradeBaseResponse struct {
 	Data json.RawMessage `json:"data"`
 }
 
+// maxBatchOrders 批量下单/撤单单次最大订单数
+const maxBatchOrders = 10
+
+// ErrLeverRateRequired 下单前没有调用SetLeverage, 也没有通过opts传入lever_rate
+var ErrLeverRateRequired = errors.New("huobi usdt swap: lever_rate required, call SetLeverage or pass lever_rate option")
+
+// USDTSwapPrvApi 火币USDT本位永续私有接口, 只接入了全仓(swap_cross)接口, 所有marginMode参数无效.
+// 火币下单必须指定杠杆倍数: 使用SetLeverage设置的值(按交易对记录), 或通过opts传入lever_rate,
+// 都没有时返回ErrLeverRateRequired, 不会使用默认值或隐式查询交易所
 type USDTSwapPrvApi struct {
 	*USDTSwap
 	apiOpts options.ApiOptions
+
+	leversMu sync.RWMutex
+	levers   map[string]int //contract_code -> lever_rate, SetLeverage设置, 下单时使用
 }
 
 func NewUSDTSwapPrvApi(apiOpts ...options.ApiOption) *USDTSwapPrvApi {
-	f := &USDTSwapPrvApi{}
+	f := &USDTSwapPrvApi{levers: make(map[string]int, 8)}
+	f.apiOpts.OrderValidators = validator.Defaults()
 	for _, opt := range apiOpts {
 		opt(&f.apiOpts)
 	}
@@ -42,21 +59,15 @@ func NewUSDTSwapPrvApi(apiOpts ...options.ApiOption) *USDTSwapPrvApi {
 }
 
 func (f *USDTSwapPrvApi) CreateOrder(pair CurrencyPair, qty, price float64, side OrderSide, orderTy OrderType, opts ...OptionParameter) (*Order, []byte, error) {
-	params := url.Values{}
-	params.Set("contract_code", pair.Symbol)
-	params.Set("price", FloatToString(price, pair.PricePrecision))
-	params.Set("volume", FloatToString(qty, pair.QtyPrecision))
-	params.Set("order_price_type", string(orderTy))
-
-	direction, offset := AdaptSideToDirectionAndOffset(side)
-	params.Set("direction", direction)
-	params.Set("offset", offset)
-
-	MergeOptionParams(&params, opts...)
+	err := validator.Chain(f.apiOpts.OrderValidators).Validate(&OrderRequest{
+		Pair: pair, Qty: qty, Price: price, Side: side, OrderTy: orderTy, Opts: opts})
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if params.Get("lever_rate") == "" {
-		logger.Warnf("[create order] set default lever rate 10")
-		params.Set("lever_rate", "10") //set default 10 lever rate
+	params, err := f.orderParams(pair, qty, price, side, orderTy, opts...)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	data, err := f.DoAuthRequest(http.MethodPost,
@@ -138,6 +149,39 @@ func (f *USDTSwapPrvApi) GetHistoryOrders(pair CurrencyPair, opts ...OptionParam
 	return orders, data, err
 }
 
+// GetFills 成交张数为合约张数, 最多返回limit条
+func (f *USDTSwapPrvApi) GetFills(pair CurrencyPair, since int64, limit int, opts ...OptionParameter) ([]Trade, []byte, error) {
+	params := url.Values{}
+	params.Set("contract", pair.Symbol)
+	params.Set("trade_type", "0")
+	if since > 0 {
+		params.Set("start_time", fmt.Sprint(since))
+	}
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoAuthRequest(http.MethodPost,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.GetFillsUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+	logger.Debugf("[GetFills] %s", string(data))
+
+	fills, err := f.unmarshalerOpts.GetFillsResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	if limit > 0 && len(fills) > limit {
+		fills = fills[:limit]
+	}
+
+	for i := range fills {
+		fills[i].Pair = pair
+	}
+
+	return fills, data, nil
+}
+
 func (f *USDTSwapPrvApi) CancelOrder(pair CurrencyPair, id string, opt ...OptionParameter) ([]byte, error) {
 	params := url.Values{}
 	params.Set("order_id", id)
@@ -157,34 +201,590 @@ func (f *USDTSwapPrvApi) CancelOrder(pair CurrencyPair, id string, opt ...Option
 	return data, f.unmarshalerOpts.CancelOrderResponseUnmarshaler(data)
 }
 
-func (f *USDTSwapPrvApi) CancelOrders(pair *CurrencyPair, id []string, opt ...OptionParameter) error {
-	//TODO implement me
-	panic("implement me")
+// CreateOrders 批量下单, 每次最多10个订单
+func (f *USDTSwapPrvApi) CreateOrders(reqs []OrderRequest, opt ...OptionParameter) ([]OrderResult, []byte, error) {
+	var (
+		results      = make([]OrderResult, len(reqs))
+		pending      []int
+		responseBody []byte
+		errs         []error
+	)
+
+	for i := range reqs {
+		err := validator.Chain(f.apiOpts.OrderValidators).Validate(&reqs[i])
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	for start := 0; start < len(pending); start += maxBatchOrders {
+		chunk := pending[start:min(start+maxBatchOrders, len(pending))]
+
+		ordersData := make([]json.RawMessage, 0, len(chunk))
+		sent := make([]int, 0, len(chunk))
+		for _, i := range chunk {
+			req := reqs[i]
+			params, err := f.orderParams(req.Pair, req.Qty, req.Price, req.Side, req.OrderTy, append(req.Opts, opt...)...)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			item, _ := ValuesToJson(params)
+			ordersData = append(ordersData, item)
+			sent = append(sent, i)
+		}
+		if chunk = sent; len(chunk) == 0 {
+			continue
+		}
+		reqBody, _ := json.Marshal(map[string]interface{}{"orders_data": ordersData})
+
+		data, err := f.doAuthRequest(http.MethodPost,
+			fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.NewOrdersUri), string(reqBody))
+		responseBody = data
+		var rets []OrderResult
+		if err == nil {
+			logger.Debugf("[CreateOrders] %s", string(data))
+			rets, err = f.unmarshalerOpts.CreateOrdersResponseUnmarshaler(data)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			for _, i := range chunk {
+				results[i].Err = err
+			}
+			continue
+		}
+
+		for j, i := range chunk {
+			if j >= len(rets) || (rets[j].Id == "" && rets[j].Err == nil) {
+				results[i].Err = errors.New("missing order result")
+				continue
+			}
+			results[i] = rets[j]
+			if rets[j].Err == nil {
+				req := reqs[i]
+				results[i].Order = &Order{
+					Pair:    req.Pair,
+					Id:      rets[j].Id,
+					CId:     rets[j].CId,
+					Price:   req.Price,
+					Qty:     req.Qty,
+					Side:    req.Side,
+					OrderTy: req.OrderTy,
+					Status:  OrderStatus_Pending,
+				}
+			}
+		}
+	}
+
+	return results, responseBody, errors.Join(errs...)
+}
+
+// CancelOrders 批量撤单, 每次最多10个订单
+func (f *USDTSwapPrvApi) CancelOrders(pair CurrencyPair, ids []string, opt ...OptionParameter) ([]OrderResult, []byte, error) {
+	var (
+		results      = make([]OrderResult, len(ids))
+		responseBody []byte
+		errs         []error
+	)
+
+	for start := 0; start < len(ids); start += maxBatchOrders {
+		end := min(start+maxBatchOrders, len(ids))
+
+		params := url.Values{}
+		params.Set("order_id", strings.Join(ids[start:end], ","))
+		params.Set("contract_code", pair.Symbol)
+		MergeOptionParams(&params, opt...)
+
+		data, err := f.DoAuthRequest(http.MethodPost,
+			fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.CancelOrdersUri), &params, nil)
+		responseBody = data
+		var rets []OrderResult
+		if err == nil {
+			rets, err = f.unmarshalerOpts.CancelOrdersResponseUnmarshaler(data)
+		}
+
+		failed := make(map[string]error, len(rets))
+		for _, ret := range rets {
+			if ret.Err != nil {
+				failed[ret.Id] = ret.Err
+			}
+		}
+
+		for i := start; i < end; i++ {
+			results[i].Id = ids[i]
+			if err != nil {
+				results[i].Err = err
+			} else {
+				results[i].Err = failed[ids[i]]
+			}
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return results, responseBody, errors.Join(errs...)
+}
+
+func (f *USDTSwapPrvApi) CancelAllOrders(pair CurrencyPair, opts ...OptionParameter) ([]byte, error) {
+	params := url.Values{}
+	params.Set("contract_code", pair.Symbol)
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoAuthRequest(http.MethodPost,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.CancelAllOrdersUri), &params, nil)
+	if err != nil {
+		return data, err
+	}
+
+	return data, f.unmarshalerOpts.CancelAllOrdersResponseUnmarshaler(data)
+}
+
+// AmendOrder 不支持原生改单, 撤单后重新下单
+func (f *USDTSwapPrvApi) AmendOrder(pair CurrencyPair, id string, newQty, newPrice float64, opts ...OptionParameter) (*AmendResult, []byte, error) {
+	return CancelReplaceOrder(f, pair, id, newQty, newPrice, opts...)
+}
+
+// GetAccount 由GetFuturesAccount转换, Balance为账户权益, FrozenBalance为冻结保证金
+func (f *USDTSwapPrvApi) GetAccount(coin string) (map[string]Account, []byte, error) {
+	futuresAcc, responseBody, err := f.GetFuturesAccount(coin)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	accounts := make(map[string]Account, len(futuresAcc))
+	for c, acc := range futuresAcc {
+		accounts[c] = Account{
+			Coin:             acc.Coin,
+			Balance:          acc.Eq,
+			AvailableBalance: acc.AvailEq,
+			FrozenBalance:    acc.FrozenBal,
+		}
+	}
+
+	return accounts, responseBody, nil
 }
 
 func (f *USDTSwapPrvApi) GetFuturesAccount(coin string) (acc map[string]FuturesAccount, responseBody []byte, err error) {
-	//TODO implement me
-	panic("implement me")
+	params := url.Values{}
+	if coin != "" {
+		params.Set("margin_account", coin)
+	}
+
+	data, err := f.DoAuthRequest(http.MethodPost,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.GetAccountUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+	logger.Debugf("[GetFuturesAccount] %s", string(data))
+
+	acc, err = f.unmarshalerOpts.GetFuturesAccountResponseUnmarshaler(data)
+	return acc, data, err
 }
 
 func (f *USDTSwapPrvApi) GetPositions(pair CurrencyPair, opts ...OptionParameter) (positions []FuturesPosition, responseBody []byte, err error) {
-	//TODO implement me
-	panic("implement me")
+	params := url.Values{}
+	if pair.Symbol != "" { //为空时返回所有持仓
+		params.Set("contract_code", pair.Symbol)
+	}
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoAuthRequest(http.MethodPost,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.GetPositionsUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+	logger.Debugf("[GetPositions] %s", string(data))
+
+	positions, err = f.unmarshalerOpts.GetPositionsResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	if pair.Symbol != "" {
+		for i := range positions {
+			positions[i].Pair = pair
+		}
+	}
+
+	return positions, data, nil
 }
 
-func (f *USDTSwapPrvApi) DoAuthRequest(method, reqUrl string, params *url.Values, header map[string]string) ([]byte, error) {
-	///////////////////// 参数签名 ////////////////////////
-	signParams := common.DoSignParam(method, reqUrl, f.apiOpts)
+// GetLeverage 全仓模式, marginMode无效
+func (f *USDTSwapPrvApi) GetLeverage(pair CurrencyPair, marginMode MarginMode, opts ...OptionParameter) (float64, []byte, error) {
+	params := url.Values{}
+	params.Set("margin_account", "USDT")
+	params.Set("contract_code", pair.Symbol)
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoAuthRequest(http.MethodPost,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.GetLeverageUri), &params, nil)
+	if err != nil {
+		return 0, data, err
+	}
+	logger.Debugf("[GetLeverage] %s", string(data))
+
+	lever, err := f.unmarshalerOpts.GetLeverageResponseUnmarshaler(data)
+	if err != nil {
+		return 0, data, err
+	}
+
+	return lever, data, nil
+}
 
-	if header == nil {
-		header = make(map[string]string, 1)
+// SetLeverage 全仓模式, marginMode无效; 火币杠杆倍数只能为正整数. 设置成功后下单默认使用该杠杆倍数
+func (f *USDTSwapPrvApi) SetLeverage(pair CurrencyPair, lever float64, marginMode MarginMode, opts ...OptionParameter) ([]byte, error) {
+	if lever < 1 || lever != math.Trunc(lever) {
+		return nil, fmt.Errorf("huobi usdt swap: lever rate must be a positive integer, got %v", lever)
 	}
-	header["Content-Type"] = "application/json"
 
+	params := url.Values{}
+	params.Set("contract_code", pair.Symbol)
+	params.Set("lever_rate", fmt.Sprint(int(lever)))
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoAuthRequest(http.MethodPost,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.SetLeverageUri), &params, nil)
+	if err != nil {
+		return data, err
+	}
+	logger.Debugf("[SetLeverage] %s", string(data))
+
+	f.setLever(pair.Symbol, int(lever))
+	return data, nil
+}
+
+// SetMarginMode 当前只接入了全仓(swap_cross)接口, 不支持切换
+func (f *USDTSwapPrvApi) SetMarginMode(pair CurrencyPair, mode MarginMode, opts ...OptionParameter) ([]byte, error) {
+	if mode == MarginMode_Cross {
+		return nil, nil
+	}
+	return nil, errors.New("huobi usdt swap only support cross margin mode")
+}
+
+func (f *USDTSwapPrvApi) SetPositionMode(mode PositionMode, opts ...OptionParameter) ([]byte, error) {
+	params := url.Values{}
+	params.Set("margin_account", "USDT")
+	if mode == PositionMode_Hedge {
+		params.Set("position_mode", "dual_side")
+	} else {
+		params.Set("position_mode", "single_side")
+	}
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoAuthRequest(http.MethodPost,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.SetPositionModeUri), &params, nil)
+	logger.Debugf("[SetPositionMode] %s", string(data))
+	return data, err
+}
+
+// AdjustMargin 全仓模式没有逐仓保证金
+func (f *USDTSwapPrvApi) AdjustMargin(pair CurrencyPair, posSide OrderSide, amount float64, opts ...OptionParameter) ([]byte, error) {
+	return nil, errors.New("huobi usdt swap cross margin mode not support adjust margin")
+}
+
+// Transfer 现货账户与U本位永续合约账户之间划转, 默认全仓保证金账户(margin-account=USDT)
+func (f *USDTSwapPrvApi) Transfer(ccy string, amount float64, from, to AccountType, opts ...OptionParameter) (*Transfer, []byte, error) {
+	fromSym, err := adaptAccountType(from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toSym, err := adaptAccountType(to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if fromSym == toSym {
+		return nil, nil, fmt.Errorf("%s and %s are the same account in huobi", from, to)
+	}
+
+	params := url.Values{}
+	params.Set("from", fromSym)
+	params.Set("to", toSym)
+	params.Set("currency", strings.ToLower(ccy))
+	params.Set("amount", FloatToString(amount, 8))
+	params.Set("margin-account", "USDT")
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoAuthRequest(http.MethodPost, f.uriOpts.SpotEndpoint+f.uriOpts.TransferUri, &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	transfer, err := f.unmarshalerOpts.TransferResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	transfer.Ccy, transfer.Amount, transfer.From, transfer.To = ccy, amount, from, to
+
+	return transfer, data, nil
+}
+
+// GetTransfer 火币划转接口同步返回结果, 没有状态查询接口
+func (f *USDTSwapPrvApi) GetTransfer(id string, from, to AccountType, opts ...OptionParameter) (*Transfer, []byte, error) {
+	return nil, nil, errors.New("huobi transfer is synchronous, no status query api")
+}
+
+// CreateAlgoOrder 止损/止盈使用计划委托(trigger), OCO使用止盈止损(tpsl, 只能平仓). 不支持追踪止损
+func (f *USDTSwapPrvApi) CreateAlgoOrder(req AlgoOrderRequest, opts ...OptionParameter) (*Order, []byte, error) {
+	if req.Side != Futures_OpenBuy && req.Side != Futures_OpenSell &&
+		req.Side != Futures_CloseBuy && req.Side != Futures_CloseSell {
+		return nil, nil, fmt.Errorf("futures algo order side error: %s", req.Side)
+	}
+
+	var (
+		params = url.Values{}
+		reqUrl string
+	)
+	direction, offset := AdaptSideToDirectionAndOffset(req.Side)
+	params.Set("contract_code", req.Pair.Symbol)
+	params.Set("volume", FloatToString(req.Qty, req.Pair.QtyPrecision))
+	params.Set("direction", direction)
+
+	switch req.OrderTy {
+	case OrderType_OCO:
+		if offset != "close" {
+			return nil, nil, errors.New("huobi tpsl order only support close position")
+		}
+		setLeg := func(leg string, triggerPx, px float64) {
+			params.Set(leg+"_trigger_price", FloatToString(triggerPx, req.Pair.PricePrecision))
+			if px > 0 {
+				params.Set(leg+"_order_price", FloatToString(px, req.Pair.PricePrecision))
+				params.Set(leg+"_order_price_type", "limit")
+			} else {
+				params.Set(leg+"_order_price_type", "optimal_5")
+			}
+		}
+		setLeg("tp", req.TpTriggerPx, req.TpPx)
+		setLeg("sl", req.SlTriggerPx, req.SlPx)
+		reqUrl = fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.NewTpslOrderUri)
+	case OrderType_StopMarket, OrderType_StopLimit, OrderType_TakeProfitMarket, OrderType_TakeProfitLimit:
+		params.Set("offset", offset)
+		params.Set("trigger_type", AdaptTriggerType(req.OrderTy, direction))
+		params.Set("trigger_price", FloatToString(req.TriggerPx, req.Pair.PricePrecision))
+		params.Set("order_price_type", AdaptAlgoOrderPriceType(req.OrderTy))
+		if req.Price > 0 {
+			params.Set("order_price", FloatToString(req.Price, req.Pair.PricePrecision))
+		}
+		reqUrl = fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.NewAlgoOrderUri)
+	default:
+		return nil, nil, fmt.Errorf("huobi usdt swap not support algo order type: %s", req.OrderTy)
+	}
+
+	MergeOptionParams(&params, append(req.Opts, opts...)...)
+	if err := adaptAlgoOrderOptionParameters(&params, req.OrderTy); err != nil {
+		return nil, nil, err
+	}
+
+	if req.OrderTy != OrderType_OCO && params.Get("lever_rate") == "" {
+		lever, err := f.lever(req.Pair)
+		if err != nil {
+			return nil, nil, err
+		}
+		params.Set("lever_rate", fmt.Sprint(lever))
+	}
+
+	data, err := f.DoAuthRequest(http.MethodPost, reqUrl, &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+	logger.Debugf("[CreateAlgoOrder] response data=%s", string(data))
+
+	ord, err := f.unmarshalerOpts.CreateAlgoOrderResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	ord.Pair = req.Pair
+	ord.Qty = req.Qty
+	ord.Price = req.Price
+	ord.Side = req.Side
+	ord.OrderTy = req.OrderTy
+	ord.TriggerPx = req.TriggerPx
+	ord.TpTriggerPx = req.TpTriggerPx
+	ord.TpPx = req.TpPx
+	ord.SlTriggerPx = req.SlTriggerPx
+	ord.SlPx = req.SlPx
+	ord.Status = OrderStatus_Pending
+
+	return ord, data, nil
+}
+
+// GetAlgoOrderInfo 没有单个条件单查询接口, 依次在当前委托和近7天历史委托中查找.
+// 止盈止损单ID(逗号连接)返回合并后的OCO订单
+func (f *USDTSwapPrvApi) GetAlgoOrderInfo(pair CurrencyPair, id string, opts ...OptionParameter) (*Order, []byte, error) {
+	ids := strings.Split(id, ",")
+
+	var legs []Order
+	find := func(orders []Order) {
+		for _, ord := range orders {
+			for _, legId := range ids {
+				if ord.Id == legId {
+					legs = append(legs, ord)
+				}
+			}
+		}
+	}
+
+	orders, data, err := f.GetPendingAlgoOrders(pair, opts...)
+	if err != nil {
+		return nil, data, err
+	}
+	find(orders)
+
+	if len(legs) < len(ids) {
+		params := url.Values{}
+		params.Set("contract_code", pair.Symbol)
+		params.Set("status", "0")
+		params.Set("create_date", "7")
+		MergeOptionParams(&params, opts...)
+
+		for _, uri := range []string{f.uriOpts.GetAlgoOrderUri, f.uriOpts.GetHistoryTpslOrdersUri} {
+			hisParams := url.Values{}
+			for k := range params {
+				hisParams.Set(k, params.Get(k))
+			}
+			if uri == f.uriOpts.GetAlgoOrderUri && hisParams.Get("trade_type") == "" {
+				hisParams.Set("trade_type", "0") //计划委托历史需要trade_type
+			}
+			orders, data, err = f.getAlgoOrders(fmt.Sprintf("%s%s", f.uriOpts.Endpoint, uri), hisParams)
+			if err != nil {
+				return nil, data, err
+			}
+			find(orders)
+		}
+	}
+
+	if len(legs) == 0 {
+		return nil, data, fmt.Errorf("algo order %s not found", id)
+	}
+
+	ord := legs[0]
+	ord.Pair = pair
+	if len(ids) > 1 { //合并止盈止损为OCO
+		ord.Id = id
+		ord.OrderTy = OrderType_OCO
+		ord.TriggerPx, ord.Price = 0, 0
+		for _, leg := range legs {
+			switch leg.OrderTy {
+			case OrderType_TakeProfitMarket, OrderType_TakeProfitLimit:
+				ord.TpTriggerPx, ord.TpPx = leg.TriggerPx, leg.Price
+			default:
+				ord.SlTriggerPx, ord.SlPx = leg.TriggerPx, leg.Price
+			}
+			if leg.Status == OrderStatus_Finished {
+				ord.Status, ord.FinishedAt = leg.Status, leg.FinishedAt
+			}
+		}
+	}
+
+	return &ord, data, nil
+}
+
+// GetPendingAlgoOrders 包括计划委托和止盈止损(单腿)的当前委托
+func (f *USDTSwapPrvApi) GetPendingAlgoOrders(pair CurrencyPair, opts ...OptionParameter) ([]Order, []byte, error) {
+	params := url.Values{}
+	params.Set("contract_code", pair.Symbol)
+	MergeOptionParams(&params, opts...)
+
+	var (
+		orders []Order
+		data   []byte
+	)
+	for _, uri := range []string{f.uriOpts.GetPendingAlgoOrdersUri, f.uriOpts.GetPendingTpslOrdersUri} {
+		ords, body, err := f.getAlgoOrders(fmt.Sprintf("%s%s", f.uriOpts.Endpoint, uri), params)
+		data = body
+		if err != nil {
+			return nil, data, err
+		}
+		orders = append(orders, ords...)
+	}
+
+	for i := range orders {
+		orders[i].Pair = pair
+	}
+
+	return orders, data, nil
+}
+
+// CancelAlgoOrder 止盈止损单ID(逗号连接)撤销止盈止损, 否则撤销计划委托, 失败时再按止盈止损单撤销
+func (f *USDTSwapPrvApi) CancelAlgoOrder(pair CurrencyPair, id string, opts ...OptionParameter) ([]byte, error) {
+	params := url.Values{}
+	params.Set("contract_code", pair.Symbol)
+	params.Set("order_id", id)
+	MergeOptionParams(&params, opts...)
+
+	uris := []string{f.uriOpts.CancelAlgoOrderUri, f.uriOpts.CancelTpslOrderUri}
+	if strings.Contains(id, ",") {
+		uris = uris[1:]
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	for _, uri := range uris {
+		data, err = f.DoAuthRequest(http.MethodPost, fmt.Sprintf("%s%s", f.uriOpts.Endpoint, uri), &params, nil)
+		if err == nil {
+			err = f.unmarshalerOpts.CancelAlgoOrderResponseUnmarshaler(data)
+		}
+		if err == nil {
+			return data, nil
+		}
+	}
+
+	return data, err
+}
+
+func (f *USDTSwapPrvApi) getAlgoOrders(reqUrl string, params url.Values) ([]Order, []byte, error) {
+	data, err := f.DoAuthRequest(http.MethodPost, reqUrl, &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+	logger.Debugf("[getAlgoOrders] %s", string(data))
+
+	orders, err := f.unmarshalerOpts.GetPendingAlgoOrdersResponseUnmarshaler(data)
+	return orders, data, err
+}
+
+func (f *USDTSwapPrvApi) setLever(symbol string, lever int) {
+	f.leversMu.Lock()
+	f.levers[symbol] = lever
+	f.leversMu.Unlock()
+}
+
+// lever 下单使用SetLeverage设置的杠杆倍数, 没有设置时返回ErrLeverRateRequired
+func (f *USDTSwapPrvApi) lever(pair CurrencyPair) (int, error) {
+	f.leversMu.RLock()
+	lever, ok := f.levers[pair.Symbol]
+	f.leversMu.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrLeverRateRequired, pair.Symbol)
+	}
+	return lever, nil
+}
+
+func (f *USDTSwapPrvApi) DoAuthRequest(method, reqUrl string, params *url.Values, header map[string]string) ([]byte, error) {
 	reqBody, _ := ValuesToJson(*params)
-	logger.Debugf("request body: %s", string(reqBody))
+	return f.doAuthRequest(method, reqUrl, string(reqBody))
+}
 
-	respBodyData, err := Cli.DoRequest(method, reqUrl+"?"+signParams.Encode(), string(reqBody), header)
+func (f *USDTSwapPrvApi) doAuthRequest(method, reqUrl, reqBody string) ([]byte, error) {
+	///////////////////// 参数签名 ////////////////////////
+	signParams, err := common.DoSignParam(method, reqUrl, f.apiOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	header := map[string]string{"Content-Type": "application/json"}
+	logger.Debugf("request body: %s", reqBody)
+
+	respBodyData, err := Cli.DoRequest(method, reqUrl+"?"+signParams.Encode(), reqBody, header)
 
 	if err != nil {
 		return nil, err
@@ -202,3 +802,31 @@ func (f *USDTSwapPrvApi) DoAuthRequest(method, reqUrl string, params *url.Values
 
 	return nil, errors.New(string(respBodyData))
 }
+
+// orderParams lever_rate未通过opts传入时使用SetLeverage设置的杠杆倍数
+func (f *USDTSwapPrvApi) orderParams(pair CurrencyPair, qty, price float64, side OrderSide, orderTy OrderType, opts ...OptionParameter) (url.Values, error) {
+	params := url.Values{}
+	params.Set("contract_code", pair.Symbol)
+	params.Set("price", FloatToString(price, pair.PricePrecision))
+	params.Set("volume", FloatToString(qty, pair.QtyPrecision))
+	params.Set("order_price_type", string(orderTy))
+
+	direction, offset := AdaptSideToDirectionAndOffset(side)
+	params.Set("direction", direction)
+	params.Set("offset", offset)
+
+	MergeOptionParams(&params, opts...)
+	if err := AdaptOrderOptionParameters(&params); err != nil {
+		return nil, err
+	}
+
+	if params.Get("lever_rate") == "" {
+		lever, err := f.lever(pair)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("lever_rate", fmt.Sprint(lever))
+	}
+
+	return params, nil
+}
@@ -10,6 +10,7 @@ import (
 	. "github.com/shadowors/goex/v2/util"
 	"net/http"
 	"net/url"
+	"slices"
 )
 
 func (f *USDTSwap) GetName() string {
@@ -43,6 +44,49 @@ func (f *USDTSwap) DoNoAuthRequest(method, reqUrl string, params *url.Values) ([
 	return respBodyData, nil
 }
 
+func (f *USDTSwap) GetExchangeInfo() (map[string]CurrencyPair, []byte, error) {
+	params := url.Values{}
+	data, err := f.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.GetExchangeInfoUri), &params)
+	if err != nil {
+		return nil, data, err
+	}
+
+	m, err := f.unmarshalerOpts.GetExchangeInfoResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	f.currencyPairM = m
+
+	return m, data, nil
+}
+
+// NewCurrencyPair 永续合约不需要参数, 交割合约传入contract alias: this_week,next_week,quarter,next_quarter
+func (f *USDTSwap) NewCurrencyPair(baseSym, quoteSym string, opts ...OptionParameter) (CurrencyPair, error) {
+	k := baseSym + quoteSym
+	if len(opts) >= 1 && opts[0].Key == "contractAlias" {
+		k += opts[0].Value
+	}
+	currencyPair := f.currencyPairM[k]
+	if currencyPair.Symbol == "" {
+		return currencyPair, errors.New("not found currency pair")
+	}
+	return currencyPair, nil
+}
+
+// GetServerTime 服务器时间(毫秒)
+func (f *USDTSwap) GetServerTime() (int64, []byte, error) {
+	data, err := f.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.GetServerTimeUri), &url.Values{})
+	if err != nil {
+		return 0, data, err
+	}
+
+	ts, err := f.unmarshalerOpts.GetServerTimeResponseUnmarshaler(data)
+	return ts, data, err
+}
+
 func (f *USDTSwap) GetDepth(pair CurrencyPair, limit int, opt ...OptionParameter) (*Depth, []byte, error) {
 	//TODO implement me
 	panic("implement me")
@@ -69,6 +113,84 @@ func (f *USDTSwap) GetTicker(pair CurrencyPair, opts ...OptionParameter) (*Ticke
 	return tk, data, nil
 }
 
+// GetTrades 最近成交, 按时间升序, limit最大2000
+func (f *USDTSwap) GetTrades(pair CurrencyPair, limit int, opts ...OptionParameter) ([]Trade, []byte, error) {
+	params := url.Values{}
+	params.Set("contract_code", pair.Symbol)
+	if limit > 0 {
+		params.Set("size", fmt.Sprint(min(limit, 2000)))
+	}
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.GetTradesUri), &params)
+	if err != nil {
+		return nil, data, err
+	}
+
+	trades, err := f.unmarshalerOpts.GetTradesResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	slices.Reverse(trades)
+	for i := range trades {
+		trades[i].Pair = pair
+	}
+
+	return trades, data, nil
+}
+
+// GetTickers 返回全部合约的聚合行情, instType无效
+func (f *USDTSwap) GetTickers(instType string, opts ...OptionParameter) (map[string]Ticker, []byte, error) {
+	params := url.Values{}
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.GetTickersUri), &params)
+	if err != nil {
+		return nil, data, err
+	}
+
+	tickers, err := f.unmarshalerOpts.GetTickersResponseUnmarshaler(data)
+	return tickers, data, err
+}
+
+// GetFundingRate Rate为本期资金费率, NextRate为预测费率
+func (f *USDTSwap) GetFundingRate(pair CurrencyPair, opts ...OptionParameter) (*FundingRate, []byte, error) {
+	params := url.Values{}
+	params.Set("contract_code", pair.Symbol)
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.GetFundingRateUri), &params)
+	if err != nil {
+		return nil, data, err
+	}
+
+	rate, err := f.unmarshalerOpts.GetFundingRateResponseUnmarshaler(data)
+	return rate, data, err
+}
+
+// GetFundingRateHistory 按时间降序, limit最大50
+func (f *USDTSwap) GetFundingRateHistory(pair CurrencyPair, limit int, opts ...OptionParameter) ([]FundingRate, []byte, error) {
+	params := url.Values{}
+	params.Set("contract_code", pair.Symbol)
+	if limit > 0 {
+		params.Set("page_size", fmt.Sprint(min(limit, 50)))
+	}
+	MergeOptionParams(&params, opts...)
+
+	data, err := f.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", f.uriOpts.Endpoint, f.uriOpts.GetFundingRateHistoryUri), &params)
+	if err != nil {
+		return nil, data, err
+	}
+
+	rates, err := f.unmarshalerOpts.GetFundingRateHistoryResponseUnmarshaler(data)
+	return rates, data, err
+}
+
 func (f *USDTSwap) GetKline(pair CurrencyPair, period KlinePeriod, opts ...OptionParameter) ([]Kline, []byte, error) {
 	params := url.Values{}
 	params.Set("contract_code", pair.Symbol)
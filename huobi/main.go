@@ -3,6 +3,7 @@ package huobi
 import (
 	"github.com/shadowors/goex/v2/huobi/futures"
 	"github.com/shadowors/goex/v2/huobi/spot"
+	"github.com/shadowors/goex/v2/options"
 )
 
 type HuoBi struct {
@@ -16,3 +17,10 @@ func New() *HuoBi {
 		Futures: futures.New(),
 	}
 }
+
+// WithEnvironment 切换实盘/AWS, 同时作用于Spot,Futures
+func (h *HuoBi) WithEnvironment(env options.Environment) *HuoBi {
+	h.Spot.WithUriOptions(spot.WithEnvironment(env))
+	h.Futures.USDTSwapFutures.WithUriOptions(futures.WithEnvironment(env))
+	return h
+}
@@ -0,0 +1,25 @@
+package spot
+
+import (
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/options"
+)
+
+// WithEnvironment 火币无测试网, 不支持的环境清空地址, 避免误连实盘
+func WithEnvironment(env options.Environment) options.UriOption {
+	return func(c *options.UriOptions) {
+		switch env {
+		case options.Env_Production:
+			c.Endpoint = "https://api.huobi.pro"
+			c.WsEndpoint = "wss://api.huobi.pro/ws"
+			c.WsPrivateEndpoint = "wss://api.huobi.pro/ws/v2"
+		case options.Env_AWS:
+			c.Endpoint = "https://api-aws.huobi.pro"
+			c.WsEndpoint = "wss://api-aws.huobi.pro/ws"
+			c.WsPrivateEndpoint = "wss://api-aws.huobi.pro/ws/v2"
+		default:
+			logger.Errorf("[huobi spot] unsupported environment: %s", env)
+			c.Endpoint, c.WsEndpoint, c.WsPrivateEndpoint = "", "", ""
+		}
+	}
+}
@@ -5,14 +5,28 @@ import (
 	"fmt"
 	. "github.com/shadowors/goex/v2/httpcli"
 	. "github.com/shadowors/goex/v2/model"
+	. "github.com/shadowors/goex/v2/util"
 	"net/http"
 	"net/url"
+	"slices"
 )
 
 func (s *Spot) GetName() string {
 	return "huobi.com"
 }
 
+// GetServerTime 服务器时间(毫秒)
+func (s *Spot) GetServerTime() (int64, []byte, error) {
+	data, err := s.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.uriOpts.Endpoint, s.uriOpts.GetServerTimeUri), nil, nil)
+	if err != nil {
+		return 0, data, err
+	}
+
+	ts, err := s.unmarshalerOpts.GetServerTimeResponseUnmarshaler(data)
+	return ts, data, err
+}
+
 func (s *Spot) GetDepth(pair CurrencyPair, limit int, opt ...OptionParameter) (*Depth, []byte, error) {
 	//TODO implement me
 	panic("implement me")
@@ -35,13 +49,77 @@ func (s *Spot) GetTicker(pair CurrencyPair, opt ...OptionParameter) (*Ticker, []
 	return tk, data, err
 }
 
+// GetTickers 返回全部交易对的24小时行情, instType无效
+func (s *Spot) GetTickers(instType string, opt ...OptionParameter) (map[string]Ticker, []byte, error) {
+	params := url.Values{}
+	MergeOptionParams(&params, opt...)
+
+	data, err := s.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.uriOpts.Endpoint, s.uriOpts.GetTickersUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	tickers, err := s.unmarshalerOpts.GetTickersResponseUnmarshaler(data)
+	return tickers, data, err
+}
+
 func (s *Spot) GetKline(pair CurrencyPair, period KlinePeriod, opt ...OptionParameter) ([]Kline, []byte, error) {
 	//TODO implement me
 	panic("implement me")
 }
 
+// GetTrades 最近成交, 按时间升序, limit最大2000
+func (s *Spot) GetTrades(pair CurrencyPair, limit int, opt ...OptionParameter) ([]Trade, []byte, error) {
+	params := url.Values{}
+	params.Set("symbol", pair.Symbol)
+	if limit > 0 {
+		params.Set("size", fmt.Sprint(min(limit, 2000)))
+	}
+	MergeOptionParams(&params, opt...)
+
+	data, err := s.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.uriOpts.Endpoint, s.uriOpts.GetTradesUri), &params, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	trades, err := s.unmarshalerOpts.GetTradesResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	slices.Reverse(trades)
+	for i := range trades {
+		trades[i].Pair = pair
+	}
+
+	return trades, data, nil
+}
+
 func (s *Spot) GetExchangeInfo() (map[string]CurrencyPair, []byte, error) {
-	panic("not implement")
+	data, err := s.DoNoAuthRequest(http.MethodGet,
+		fmt.Sprintf("%s%s", s.uriOpts.Endpoint, s.uriOpts.GetExchangeInfoUri), nil, nil)
+	if err != nil {
+		return nil, data, err
+	}
+
+	m, err := s.unmarshalerOpts.GetExchangeInfoResponseUnmarshaler(data)
+	if err != nil {
+		return nil, data, err
+	}
+
+	s.currencyPairM = m
+
+	return m, data, nil
+}
+
+func (s *Spot) NewCurrencyPair(baseSym, quoteSym string, opts ...OptionParameter) (CurrencyPair, error) {
+	currencyPair := s.currencyPairM[baseSym+quoteSym]
+	if currencyPair.Symbol == "" {
+		return currencyPair, errors.New("not found currency pair")
+	}
+	return currencyPair, nil
 }
 
 func (s *Spot) DoNoAuthRequest(method, reqUrl string, params *url.Values, headers map[string]string) ([]byte, error) {
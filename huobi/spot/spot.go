@@ -18,12 +18,15 @@ type BaseResponse struct {
 type Spot struct {
 	uriOpts         UriOptions
 	unmarshalerOpts UnmarshalerOptions
+	currencyPairM   map[string]CurrencyPair
 }
 
 func New() *Spot {
 	s := &Spot{
 		uriOpts: UriOptions{
 			Endpoint:            "https://api.huobi.pro",
+			WsEndpoint:          "wss://api.huobi.pro/ws",
+			WsPrivateEndpoint:   "wss://api.huobi.pro/ws/v2",
 			TickerUri:           "/market/detail/merged",
 			DepthUri:            "",
 			KlineUri:            "",
@@ -32,12 +35,21 @@ func New() *Spot {
 			GetHistoryOrdersUri: "",
 			CancelOrderUri:      "",
 			NewOrderUri:         "",
+			GetExchangeInfoUri:  "/v1/common/symbols",
+			GetTradesUri:        "/market/history/trade",
+			GetTickersUri:       "/market/tickers",
+			GetServerTimeUri:    "/v1/common/timestamp",
 		},
 		unmarshalerOpts: UnmarshalerOptions{
-			ResponseUnmarshaler: UnmarshalResponse,
-			TickerUnmarshaler:   UnmarshalTicker,
-			DepthUnmarshaler:    UnmarshalDepth,
+			ResponseUnmarshaler:                UnmarshalResponse,
+			TickerUnmarshaler:                  UnmarshalTicker,
+			DepthUnmarshaler:                   UnmarshalDepth,
+			GetExchangeInfoResponseUnmarshaler: UnmarshalGetExchangeInfoResponse,
+			GetTradesResponseUnmarshaler:       UnmarshalGetTradesResponse,
+			GetTickersResponseUnmarshaler:      UnmarshalGetTickersResponse,
+			GetServerTimeResponseUnmarshaler:   UnmarshalGetServerTimeResponse,
 		},
+		currencyPairM: make(map[string]CurrencyPair, 64),
 	}
 
 	return s
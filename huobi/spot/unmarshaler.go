@@ -5,6 +5,8 @@ import (
 	"github.com/buger/jsonparser"
 	. "github.com/shadowors/goex/v2/model"
 	"github.com/spf13/cast"
+	"math"
+	"strings"
 )
 
 func UnmarshalResponse(data []byte, i interface{}) error {
@@ -16,48 +18,175 @@ func UnmarshalDepth(data []byte) (*Depth, error) {
 	panic("implement me")
 }
 
+func UnmarshalGetServerTimeResponse(data []byte) (int64, error) {
+	return jsonparser.GetInt(data, "data")
+}
+
 func UnmarshalTicker(data []byte) (*Ticker, error) {
-	var (
-		tk   = new(Ticker)
-		open float64
-	)
+	var tk = new(Ticker)
 
 	tk.Timestamp, _ = jsonparser.GetInt(data, "ts")
 	tickData, _, _, _ := jsonparser.Get(data, "tick")
-	err := jsonparser.ObjectEach(tickData, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+	err := unmarshalTicker(tickData, tk)
+	if err != nil {
+		return nil, err
+	}
+
+	return tk, nil
+}
+
+// UnmarshalGetTickersResponse key为symbol
+func UnmarshalGetTickersResponse(data []byte) (map[string]Ticker, error) {
+	var tickers = make(map[string]Ticker, 512)
+
+	ts, _ := jsonparser.GetInt(data, "ts")
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var tk = Ticker{Timestamp: ts}
+		if unmarshalTicker(value, &tk) != nil {
+			return
+		}
+		tickers[tk.Pair.Symbol] = tk
+	}, "data")
+
+	return tickers, err
+}
+
+// unmarshalTicker amount为交易币成交量, vol为计价币成交额
+func unmarshalTicker(data []byte, tk *Ticker) error {
+	var open float64
+
+	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
 		switch string(key) {
+		case "symbol":
+			tk.Pair.Symbol = string(value)
 		case "close":
 			tk.Last = cast.ToFloat64(string(value))
 		case "high":
 			tk.High = cast.ToFloat64(string(value))
 		case "low":
 			tk.Low = cast.ToFloat64(string(value))
-		case "vol":
+		case "amount":
 			tk.Vol = cast.ToFloat64(string(value))
+		case "vol":
+			tk.QuoteVol = cast.ToFloat64(string(value))
 		case "open":
 			open = cast.ToFloat64(string(value))
 		case "bid":
+			if dataType != jsonparser.Array { // /market/tickers 为单个价格
+				tk.Buy = cast.ToFloat64(string(value))
+				return nil
+			}
 			var bids []float64
 			err := UnmarshalResponse(value, &bids)
 			if err != nil {
 				return err
 			}
-			tk.Buy = bids[0]
+			if len(bids) == 2 {
+				tk.Buy, tk.BuyQty = bids[0], bids[1]
+			}
 		case "ask":
+			if dataType != jsonparser.Array {
+				tk.Sell = cast.ToFloat64(string(value))
+				return nil
+			}
 			var asks []float64
 			err := UnmarshalResponse(value, &asks)
 			if err != nil {
 				return err
 			}
-			tk.Sell = asks[0]
+			if len(asks) == 2 {
+				tk.Sell, tk.SellQty = asks[0], asks[1]
+			}
+		case "bidSize":
+			tk.BuyQty = cast.ToFloat64(string(value))
+		case "askSize":
+			tk.SellQty = cast.ToFloat64(string(value))
 		}
 		return nil
 	})
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	tk.Percent = (tk.Last - open) / open * 100
-	return tk, nil
+	if open > 0 {
+		tk.Percent = (tk.Last - open) / open * 100
+	}
+	return nil
+}
+
+// UnmarshalGetTradesResponse 按批次返回, 每批包含多笔成交
+func UnmarshalGetTradesResponse(data []byte) ([]Trade, error) {
+	var trades []Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		_, err = jsonparser.ArrayEach(value, func(tradeData []byte, dataType jsonparser.ValueType, offset int, err error) {
+			var trade Trade
+			err = jsonparser.ObjectEach(tradeData, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+				valStr := string(val)
+				switch string(key) {
+				case "trade-id":
+					trade.Id = valStr
+				case "price":
+					trade.Price = cast.ToFloat64(valStr)
+				case "amount":
+					trade.Qty = cast.ToFloat64(valStr)
+				case "ts":
+					trade.Ts = cast.ToInt64(valStr)
+				case "direction":
+					trade.Side = OrderSide(valStr)
+				}
+				return nil
+			})
+			trades = append(trades, trade)
+		}, "data")
+	}, "data")
+	return trades, err
+}
+
+func UnmarshalGetExchangeInfoResponse(data []byte) (map[string]CurrencyPair, error) {
+	var currencyPairM = make(map[string]CurrencyPair, 64)
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			currencyPair CurrencyPair
+			state        string
+		)
+
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "symbol":
+				currencyPair.Symbol = valStr
+			case "base-currency":
+				currencyPair.BaseSymbol = strings.ToUpper(valStr)
+			case "quote-currency":
+				currencyPair.QuoteSymbol = strings.ToUpper(valStr)
+			case "price-precision":
+				currencyPair.PricePrecision = cast.ToInt(valStr)
+				currencyPair.TickSize = math.Pow10(-currencyPair.PricePrecision)
+			case "amount-precision":
+				currencyPair.QtyPrecision = cast.ToInt(valStr)
+				currencyPair.LotSize = math.Pow10(-currencyPair.QtyPrecision)
+			case "limit-order-min-order-amt":
+				currencyPair.MinQty = cast.ToFloat64(valStr)
+			case "limit-order-max-order-amt":
+				currencyPair.MaxQty = cast.ToFloat64(valStr)
+			case "sell-market-max-order-amt":
+				currencyPair.MarketQty = cast.ToFloat64(valStr)
+			case "min-order-value":
+				currencyPair.MinNotional = cast.ToFloat64(valStr)
+			case "state":
+				state = valStr
+			}
+			return nil
+		})
+
+		if state != "" && state != "online" {
+			return
+		}
+
+		currencyPairM[currencyPair.BaseSymbol+currencyPair.QuoteSymbol] = currencyPair
+	}, "data")
+
+	return currencyPairM, err
 }
@@ -0,0 +1,77 @@
+package instrument
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shadowors/goex/v2/model"
+)
+
+type Type string
+
+const (
+	Type_Spot    Type = "SPOT"
+	Type_Swap    Type = "SWAP"    //永续合约
+	Type_Futures Type = "FUTURES" //交割合约
+)
+
+// Instrument 统一格式的交易品种, 不同交易所的相同品种Id相同:
+//
+//	现货:     BTC-USDT
+//	永续合约: BTC-USDT-SWAP, 币本位 BTC-USD-SWAP
+//	交割合约: BTC-USDT-250328 (交割日期, UTC)
+type Instrument struct {
+	Venue   string             `json:"venue"` //见goex.Venues(), 如binance.usdm
+	Id      string             `json:"id"`
+	Type    Type               `json:"type"`
+	Base    string             `json:"base"`
+	Quote   string             `json:"quote"`
+	Settle  string             `json:"settle,omitempty"` //结算币, 现货为空
+	Inverse bool               `json:"inverse,omitempty"`
+	Expiry  int64              `json:"expiry,omitempty"` //交割时间(毫秒), 仅交割合约
+	Pair    model.CurrencyPair `json:"pair"`             //交易所原始交易对, 用于下单
+}
+
+// Normalize 由交易所返回的CurrencyPair生成统一格式的Instrument
+func Normalize(venue string, pair model.CurrencyPair) Instrument {
+	inst := Instrument{
+		Venue: venue,
+		Base:  strings.ToUpper(pair.BaseSymbol),
+		Quote: strings.ToUpper(pair.QuoteSymbol),
+		Pair:  pair,
+	}
+
+	switch {
+	case pair.ContractVal == 0 && pair.SettlementCurrency == "" && pair.ContractDeliveryDate == 0:
+		inst.Type = Type_Spot
+	case pair.ContractDeliveryDate == 0 || strings.EqualFold(pair.ContractAlias, "PERPETUAL"):
+		inst.Type = Type_Swap
+	default:
+		inst.Type = Type_Futures
+		inst.Expiry = pair.ContractDeliveryDate
+	}
+
+	if inst.Type != Type_Spot {
+		inst.Settle = strings.ToUpper(pair.SettlementCurrency)
+		if inst.Settle == "" {
+			inst.Settle = inst.Quote
+		}
+		inst.Inverse = inst.Settle == inst.Base
+	}
+
+	inst.Id = MakeId(inst.Base, inst.Quote, inst.Type, inst.Expiry)
+
+	return inst
+}
+
+// MakeId 统一格式的Id, expiry仅交割合约需要
+func MakeId(base, quote string, typ Type, expiry int64) string {
+	id := strings.ToUpper(base) + "-" + strings.ToUpper(quote)
+	switch typ {
+	case Type_Swap:
+		id += "-SWAP"
+	case Type_Futures:
+		id += "-" + time.UnixMilli(expiry).UTC().Format("060102")
+	}
+	return id
+}
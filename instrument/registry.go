@@ -0,0 +1,346 @@
+package instrument
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shadowors/goex/v2"
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/model"
+)
+
+// Loader 加载一个交易所市场的全部交易对, 通常为IPubRest.GetExchangeInfo
+type Loader func() (map[string]model.CurrencyPair, error)
+
+// DefaultRegistry 进程内共享的注册表
+var DefaultRegistry = NewRegistry()
+
+// Registry 线程安全的交易品种注册表, 按交易所市场加载并定时刷新,
+// 可按统一Id在不同交易所之间查找相同品种, 并缓存到本地文件, 启动时加载失败的交易所使用缓存
+type Registry struct {
+	mu        sync.RWMutex
+	loaders   map[string]Loader
+	bySymbol  map[string]map[string]Instrument //venue -> symbol
+	byId      map[string]map[string]Instrument //id -> venue
+	updatedAt map[string]int64                 //venue -> 最近一次加载时间(毫秒)
+	cacheFile string
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		loaders:   make(map[string]Loader, 8),
+		bySymbol:  make(map[string]map[string]Instrument, 8),
+		byId:      make(map[string]map[string]Instrument, 256),
+		updatedAt: make(map[string]int64, 8),
+	}
+}
+
+// WithCacheFile 设置本地缓存文件, 每次刷新成功后写入
+func (r *Registry) WithCacheFile(path string) *Registry {
+	r.mu.Lock()
+	r.cacheFile = path
+	r.mu.Unlock()
+	return r
+}
+
+// AddVenue 以api.GetExchangeInfo加载, 加载的同时也使api.NewCurrencyPair可用;
+// 缓存数据不会写入api, 因此Start总是同步刷新一次
+//
+//	registry.AddVenue(model.OKX_SWAP, goex.OKx.Swap)
+func (r *Registry) AddVenue(venue string, api goex.IPubRest) *Registry {
+	return r.AddLoader(venue, func() (map[string]model.CurrencyPair, error) {
+		pairs, _, err := api.GetExchangeInfo()
+		return pairs, err
+	})
+}
+
+func (r *Registry) AddLoader(venue string, loader Loader) *Registry {
+	r.mu.Lock()
+	r.loaders[venue] = loader
+	r.mu.Unlock()
+	return r
+}
+
+// Refresh 重新加载所有交易所市场, 加载失败的保留原有数据, 返回最后一个错误
+func (r *Registry) Refresh() error {
+	r.mu.RLock()
+	loaders := make(map[string]Loader, len(r.loaders))
+	for venue, loader := range r.loaders {
+		loaders[venue] = loader
+	}
+	r.mu.RUnlock()
+
+	var (
+		lastErr error
+		updated bool
+	)
+
+	for venue, loader := range loaders {
+		pairs, err := loader()
+		if err != nil {
+			logger.Warnf("[instrument] load %s error: %s", venue, err.Error())
+			lastErr = fmt.Errorf("load %s: %w", venue, err)
+			continue
+		}
+
+		insts := make([]Instrument, 0, len(pairs))
+		for _, pair := range pairs {
+			insts = append(insts, Normalize(venue, pair))
+		}
+
+		r.set(venue, insts, time.Now().UnixMilli())
+		updated = true
+	}
+
+	if updated {
+		if err := r.SaveCache(); err != nil {
+			logger.Warnf("[instrument] save cache error: %s", err.Error())
+		}
+	}
+
+	return lastErr
+}
+
+func (r *Registry) set(venue string, insts []Instrument, updatedAt int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, old := range r.bySymbol[venue] {
+		delete(r.byId[old.Id], venue)
+		if len(r.byId[old.Id]) == 0 {
+			delete(r.byId, old.Id)
+		}
+	}
+
+	symbols := make(map[string]Instrument, len(insts))
+	for _, inst := range insts {
+		symbols[inst.Pair.Symbol] = inst
+		if r.byId[inst.Id] == nil {
+			r.byId[inst.Id] = make(map[string]Instrument, 4)
+		}
+		r.byId[inst.Id][venue] = inst
+	}
+
+	r.bySymbol[venue] = symbols
+	r.updatedAt[venue] = updatedAt
+}
+
+// Get 按交易所原始symbol查找, 如: Get(model.BINANCE_USDM, "BTCUSDT")
+func (r *Registry) Get(venue, symbol string) (Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	inst, ok := r.bySymbol[venue][symbol]
+	return inst, ok
+}
+
+// Find 按统一Id查找, 如: Find(model.OKX_SWAP, "BTC-USDT-SWAP")
+func (r *Registry) Find(venue, id string) (Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	inst, ok := r.byId[id][venue]
+	return inst, ok
+}
+
+// CurrencyPair 按统一Id返回交易所的交易对, 未加载时返回错误
+func (r *Registry) CurrencyPair(venue, id string) (model.CurrencyPair, error) {
+	inst, ok := r.Find(venue, id)
+	if !ok {
+		return model.CurrencyPair{}, fmt.Errorf("instrument %s not found in %s", id, venue)
+	}
+	return inst.Pair, nil
+}
+
+// Equivalents 统一Id相同的所有交易所品种, key为venue
+func (r *Registry) Equivalents(id string) map[string]Instrument {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ret := make(map[string]Instrument, len(r.byId[id]))
+	for venue, inst := range r.byId[id] {
+		ret[venue] = inst
+	}
+	return ret
+}
+
+// Map 查找其他交易所的相同品种, 如: Map(model.OKX_SWAP, "BTC-USDT-SWAP", model.BINANCE_USDM) -> BTCUSDT
+func (r *Registry) Map(fromVenue, symbol, toVenue string) (Instrument, bool) {
+	inst, ok := r.Get(fromVenue, symbol)
+	if !ok {
+		return Instrument{}, false
+	}
+	return r.Find(toVenue, inst.Id)
+}
+
+// Instruments 按Id升序
+func (r *Registry) Instruments(venue string) []Instrument {
+	r.mu.RLock()
+	insts := make([]Instrument, 0, len(r.bySymbol[venue]))
+	for _, inst := range r.bySymbol[venue] {
+		insts = append(insts, inst)
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(insts, func(a, b Instrument) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return insts
+}
+
+// UpdatedAt 最近一次加载时间(毫秒), 包括从缓存文件加载
+func (r *Registry) UpdatedAt(venue string) int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.updatedAt[venue]
+}
+
+type cacheVenue struct {
+	UpdatedAt   int64        `json:"updated_at"`
+	Instruments []Instrument `json:"instruments"`
+}
+
+// SaveCache 写入缓存文件, 先写临时文件再rename保证文件完整; 未设置缓存文件时忽略
+func (r *Registry) SaveCache() error {
+	r.mu.RLock()
+	path := r.cacheFile
+	cache := make(map[string]cacheVenue, len(r.bySymbol))
+	for venue, symbols := range r.bySymbol {
+		insts := make([]Instrument, 0, len(symbols))
+		for _, inst := range symbols {
+			insts = append(insts, inst)
+		}
+		cache[venue] = cacheVenue{UpdatedAt: r.updatedAt[venue], Instruments: insts}
+	}
+	r.mu.RUnlock()
+
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadCache 从缓存文件加载, 文件不存在时忽略
+func (r *Registry) LoadCache() error {
+	r.mu.RLock()
+	path := r.cacheFile
+	r.mu.RUnlock()
+
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var cache map[string]cacheVenue
+	if err = json.Unmarshal(data, &cache); err != nil {
+		return err
+	}
+
+	for venue, c := range cache {
+		r.set(venue, c.Instruments, c.UpdatedAt)
+	}
+
+	return nil
+}
+
+// Start 先从缓存文件加载, 再同步刷新一次使AddVenue的api.NewCurrencyPair可用,
+// 刷新失败的交易所保留缓存数据; 之后后台按interval刷新.
+// 所有交易所都有数据(包括缓存)时返回nil, 否则返回刷新错误
+func (r *Registry) Start(interval time.Duration) error {
+	if err := r.LoadCache(); err != nil {
+		logger.Warnf("[instrument] load cache error: %s", err.Error())
+	}
+
+	err := r.Refresh()
+	if err != nil && r.hasAll() {
+		logger.Warnf("[instrument] refresh error, use cache: %s", err.Error())
+		err = nil
+	}
+
+	r.mu.Lock()
+	if r.stopCh != nil {
+		r.mu.Unlock()
+		return err
+	}
+	r.stopCh = make(chan struct{})
+	stopCh := r.stopCh
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				_ = r.Refresh()
+			}
+		}
+	}()
+
+	return err
+}
+
+// Stop 停止后台刷新
+func (r *Registry) Stop() {
+	r.mu.Lock()
+	stopCh := r.stopCh
+	r.stopCh = nil
+	r.mu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		r.wg.Wait()
+	}
+}
+
+// hasAll 所有交易所市场都已有数据
+func (r *Registry) hasAll() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for venue := range r.loaders {
+		if len(r.bySymbol[venue]) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -19,6 +19,7 @@ const (
 	OrderStatus_Canceled                 = 3
 	OrderStatus_PartFinished             = 4
 	OrderStatus_Canceling                = 5
+	OrderStatus_Rejected                 = 6
 )
 
 const (
@@ -34,6 +35,14 @@ const (
 	OrderType_Limit    OrderType = "limit"
 	OrderType_Market   OrderType = "market"
 	OrderType_opponent OrderType = "opponent"
+
+	//条件单/策略委托, 通过IAlgoOrderRest下单
+	OrderType_StopMarket       OrderType = "stop_market"        //止损市价
+	OrderType_StopLimit        OrderType = "stop_limit"         //止损限价
+	OrderType_TakeProfitMarket OrderType = "take_profit_market" //止盈市价
+	OrderType_TakeProfitLimit  OrderType = "take_profit_limit"  //止盈限价
+	OrderType_TrailingStop     OrderType = "trailing_stop"      //追踪止损
+	OrderType_OCO              OrderType = "oco"                //止盈止损二选一
 )
 
 //coin const list
@@ -141,8 +150,86 @@ const (
 const (
 	OKX     = "okx.com"
 	BINANCE = "binance.com"
+	HUOBI   = "huobi.com"
+)
+
+//venue name const list, 见goex.NewPub/goex.NewPrv: 交易所.市场
+const (
+	OKX_SPOT          = "okx.spot"
+	OKX_FUTURES       = "okx.futures"
+	OKX_SWAP          = "okx.swap"
+	BINANCE_SPOT      = "binance.spot"
+	BINANCE_USDM      = "binance.usdm"
+	BINANCE_COINM     = "binance.coinm" //仅资金费率等公共接口, 未注册到goex.NewPub
+	HUOBI_SPOT        = "huobi.spot"
+	HUOBI_LINEAR_SWAP = "huobi.linear_swap"
+)
+
+const (
+	Order_Client_ID__Opt_Key       = "OrderClientID"
+	Time_In_Force__Opt_Key         = "TimeInForce"
+	Post_Only__Opt_Key             = "PostOnly"
+	Reduce_Only__Opt_Key           = "ReduceOnly"
+	Close_Position__Opt_Key        = "ClosePosition"
+	Self_Trade_Prevention__Opt_Key = "SelfTradePrevention"
+	Price_Match__Opt_Key           = "PriceMatch"
+)
+
+// 改单方式
+const (
+	AmendMode_Native        AmendMode = iota + 1 //交易所原生改单, 订单ID不变
+	AmendMode_CancelReplace                      //撤单后重新下单, 返回新的订单ID
+)
+
+// 保证金模式
+const (
+	MarginMode_Isolated MarginMode = "isolated" //逐仓
+	MarginMode_Cross    MarginMode = "cross"    //全仓
+)
+
+// 持仓模式
+const (
+	PositionMode_OneWay PositionMode = "one_way" //单向持仓
+	PositionMode_Hedge  PositionMode = "hedge"   //双向持仓
+)
+
+// 资金划转账户类型, 交易所不支持的类型返回错误
+const (
+	AccountType_Funding     AccountType = "funding"      //资金账户
+	AccountType_Trading     AccountType = "trading"      //交易账户, okx统一账户; binance、huobi同现货账户
+	AccountType_Spot        AccountType = "spot"         //现货账户
+	AccountType_UsdtFutures AccountType = "usdt_futures" //U本位合约账户(USDⓈ-M)
+	AccountType_CoinFutures AccountType = "coin_futures" //币本位合约账户(COIN-M)
+	AccountType_Margin      AccountType = "margin"       //全仓杠杆账户
+)
+
+// 资金划转状态
+const (
+	TransferStatus_Pending TransferStatus = "pending" //处理中
+	TransferStatus_Success TransferStatus = "success" //成功
+	TransferStatus_Failed  TransferStatus = "failed"  //失败
+)
+
+const (
+	TimeInForce_GTC TimeInForce = "GTC" //成交为止
+	TimeInForce_IOC TimeInForce = "IOC" //立即成交并取消剩余
+	TimeInForce_FOK TimeInForce = "FOK" //全部成交或立即取消
+	TimeInForce_GTX TimeInForce = "GTX" //只做maker
+)
+
+const (
+	STPMode_CancelTaker STPMode = "cancel_taker"
+	STPMode_CancelMaker STPMode = "cancel_maker"
+	STPMode_CancelBoth  STPMode = "cancel_both"
 )
 
 const (
-	Order_Client_ID__Opt_Key = "OrderClientID"
+	PriceMatch_Opponent   PriceMatch = "OPPONENT"    //对手价
+	PriceMatch_Opponent5  PriceMatch = "OPPONENT_5"  //对手价第5档
+	PriceMatch_Opponent10 PriceMatch = "OPPONENT_10" //对手价第10档
+	PriceMatch_Opponent20 PriceMatch = "OPPONENT_20" //对手价第20档
+	PriceMatch_Queue      PriceMatch = "QUEUE"       //同向第1档
+	PriceMatch_Queue5     PriceMatch = "QUEUE_5"
+	PriceMatch_Queue10    PriceMatch = "QUEUE_10"
+	PriceMatch_Queue20    PriceMatch = "QUEUE_20"
 )
@@ -20,10 +20,43 @@ func (s OrderStatus) String() string {
 		return "canceled"
 	case 4:
 		return "part-finished"
+	case 5:
+		return "canceling"
+	case 6:
+		return "rejected"
 	}
 	return "unknown-status"
 }
 
+type AmendMode int
+
+type MarginMode string
+
+type PositionMode string
+
+type TimeInForce string
+
+// STPMode 自成交保护模式
+type STPMode string
+
+// PriceMatch 按盘口价格下单, 不需要传入价格
+type PriceMatch string
+
+// AccountType 资金划转的账户类型
+type AccountType string
+
+type TransferStatus string
+
+func (m AmendMode) String() string {
+	switch m {
+	case AmendMode_Native:
+		return "native"
+	case AmendMode_CancelReplace:
+		return "cancel-replace"
+	}
+	return "unknown"
+}
+
 // OptionParameter is api option parameter
 type OptionParameter struct {
 	Key   string
@@ -37,15 +70,103 @@ func (OptionParameter) OrderClientID(cid string) OptionParameter {
 	}
 }
 
+// TimeInForce 订单有效方式, TimeInForce_GTX等同于PostOnly
+func (OptionParameter) TimeInForce(tif TimeInForce) OptionParameter {
+	return OptionParameter{Key: Time_In_Force__Opt_Key, Value: string(tif)}
+}
+
+// PostOnly 只做maker
+func (OptionParameter) PostOnly() OptionParameter {
+	return OptionParameter{Key: Post_Only__Opt_Key, Value: "true"}
+}
+
+// ReduceOnly 只减仓
+func (OptionParameter) ReduceOnly() OptionParameter {
+	return OptionParameter{Key: Reduce_Only__Opt_Key, Value: "true"}
+}
+
+// ClosePosition 触发后全部平仓, 只用于条件单
+func (OptionParameter) ClosePosition() OptionParameter {
+	return OptionParameter{Key: Close_Position__Opt_Key, Value: "true"}
+}
+
+func (OptionParameter) SelfTradePrevention(mode STPMode) OptionParameter {
+	return OptionParameter{Key: Self_Trade_Prevention__Opt_Key, Value: string(mode)}
+}
+
+func (OptionParameter) PriceMatch(pm PriceMatch) OptionParameter {
+	return OptionParameter{Key: Price_Match__Opt_Key, Value: string(pm)}
+}
+
+// OrderRequest 下单请求参数, 用于下单前校验及批量下单
+type OrderRequest struct {
+	Pair    CurrencyPair      `json:"pair"`
+	Qty     float64           `json:"qty"`
+	Price   float64           `json:"price"`
+	Side    OrderSide         `json:"side"`
+	OrderTy OrderType         `json:"order_ty"`
+	Opts    []OptionParameter `json:"opts,omitempty"`
+}
+
+// AlgoOrderRequest 条件单下单参数, OrderTy为OrderType_StopMarket等条件单类型
+type AlgoOrderRequest struct {
+	Pair         CurrencyPair      `json:"pair"`
+	Qty          float64           `json:"qty"`
+	Side         OrderSide         `json:"side"`
+	OrderTy      OrderType         `json:"order_ty"`
+	Price        float64           `json:"price,omitempty"`         //触发后的委托价格, 限价类型使用
+	TriggerPx    float64           `json:"trigger_px,omitempty"`    //触发价格; 追踪止损为激活价格, 0为立即激活
+	CallbackRate float64           `json:"callback_rate,omitempty"` //追踪止损回调比例, 如: 0.01为1%
+	TpTriggerPx  float64           `json:"tp_trigger_px,omitempty"` //OCO止盈触发价格
+	TpPx         float64           `json:"tp_px,omitempty"`         //OCO止盈委托价格, 0为市价
+	SlTriggerPx  float64           `json:"sl_trigger_px,omitempty"` //OCO止损触发价格
+	SlPx         float64           `json:"sl_px,omitempty"`         //OCO止损委托价格, 0为市价
+	Opts         []OptionParameter `json:"opts,omitempty"`
+}
+
+// Opt 获取下单请求中的参数值
+func (req *OrderRequest) Opt(key string) string {
+	for _, opt := range req.Opts {
+		if opt.Key == key {
+			return opt.Value
+		}
+	}
+	return ""
+}
+
+// OrderResult 批量下单/撤单中单个订单的结果, 与请求顺序一一对应
+type OrderResult struct {
+	Id    string `json:"id,omitempty"`    //交易所订单ID
+	CId   string `json:"cid,omitempty"`   //客户端订单ID
+	Order *Order `json:"order,omitempty"` //下单成功时的订单信息
+	Err   error  `json:"-"`
+}
+
+func (r OrderResult) Success() bool {
+	return r.Err == nil
+}
+
+// AmendResult 改单结果
+type AmendResult struct {
+	Mode   AmendMode `json:"mode"`
+	OrigId string    `json:"orig_id"` //原订单ID
+	Order  *Order    `json:"order"`   //改单后的订单, 撤单重下时为新订单
+}
+
 type CurrencyPair struct {
 	Symbol               string  `json:"symbol,omitempty"`          //交易对
 	BaseSymbol           string  `json:"base_symbol,omitempty"`     //币种
 	QuoteSymbol          string  `json:"quote_symbol,omitempty"`    //交易区：usdt/usdc/btc ...
 	PricePrecision       int     `json:"price_precision,omitempty"` //价格小数点位数
 	QtyPrecision         int     `json:"qty_precision,omitempty"`   //数量小数点位数
+	TickSize             float64 `json:"tick_size,omitempty"`       //价格最小变动单位
+	LotSize              float64 `json:"lot_size,omitempty"`        //数量最小变动单位
 	MinQty               float64 `json:"min_qty,omitempty"`
-	MaxQty               float64 `json:"max_qty,omitempty"`
-	MarketQty            float64 `json:"market_qty,omitempty"`
+	MaxQty               float64 `json:"max_qty,omitempty"`                //限价单最大下单数量
+	MarketQty            float64 `json:"market_qty,omitempty"`             //市价单最大下单数量
+	MinNotional          float64 `json:"min_notional,omitempty"`           //最小下单金额(qty*price)
+	MultiplierUp         float64 `json:"multiplier_up,omitempty"`          //限价单价格上限系数(相对标记价格)
+	MultiplierDown       float64 `json:"multiplier_down,omitempty"`        //限价单价格下限系数(相对标记价格)
 	ContractVal          float64 `json:"contract_val,omitempty"`           //1张合约价值
 	ContractValCurrency  string  `json:"contract_val_currency,omitempty"`  //合约面值计价币
 	SettlementCurrency   string  `json:"settlement_currency,omitempty"`    //结算币
@@ -71,7 +192,10 @@ type Ticker struct {
 	Sell      float64      `json:"s"`
 	High      float64      `json:"h"`
 	Low       float64      `json:"lw"`
+	BuyQty    float64      `json:"bq"` //买一量
+	SellQty   float64      `json:"sq"` //卖一量
 	Vol       float64      `json:"v"`
+	QuoteVol  float64      `json:"qv"` //计价币成交额
 	Percent   float64      `json:"percent"`
 	Timestamp int64        `json:"t"`
 }
@@ -128,6 +252,28 @@ type Order struct {
 	CreatedAt   int64        `json:"created_at,omitempty"`
 	FinishedAt  int64        `json:"finished_at,omitempty"` //订单完成时间
 	CanceledAt  int64        `json:"canceled_at,omitempty"`
+
+	//条件单字段
+	TriggerPx    float64 `json:"trigger_px,omitempty"`    //触发价格, 追踪止损为激活价格
+	CallbackRate float64 `json:"callback_rate,omitempty"` //追踪止损回调比例
+	TpTriggerPx  float64 `json:"tp_trigger_px,omitempty"` //OCO止盈触发价格
+	TpPx         float64 `json:"tp_px,omitempty"`         //OCO止盈委托价格, 0为市价
+	SlTriggerPx  float64 `json:"sl_trigger_px,omitempty"` //OCO止损触发价格
+	SlPx         float64 `json:"sl_px,omitempty"`         //OCO止损委托价格, 0为市价
+}
+
+// Trade 成交明细
+type Trade struct {
+	Pair    CurrencyPair `json:"pair,omitempty"`
+	Id      string       `json:"id,omitempty"`       //成交ID
+	OrderId string       `json:"order_id,omitempty"` //订单ID
+	Side    OrderSide    `json:"side,omitempty"`     //公共成交为主动成交方向
+	Price   float64      `json:"price,omitempty"`
+	Qty     float64      `json:"qty,omitempty"`
+	Fee     float64      `json:"fee,omitempty"`     //手续费, 正数为支出, 负数为返佣
+	FeeCcy  string       `json:"fee_ccy,omitempty"` //手续费币种
+	IsMaker bool         `json:"is_maker,omitempty"`
+	Ts      int64        `json:"ts,omitempty"` //成交时间(毫秒)
 }
 
 type Account struct {
@@ -147,6 +293,8 @@ type FuturesPosition struct {
 	Upl      float64      `json:"upl,omitempty"`       //盈亏
 	UplRatio float64      `json:"upl_ratio,omitempty"` // 盈亏率
 	Lever    float64      `json:"lever,omitempty"`     //杠杆倍数
+	Margin   float64      `json:"margin,omitempty"`    //占用保证金
+	Rpl      float64      `json:"rpl,omitempty"`       //已实现盈亏
 }
 
 type FuturesAccount struct {
@@ -160,9 +308,22 @@ type FuturesAccount struct {
 }
 
 type FundingRate struct {
-	Symbol string  `json:"symbol"`
-	Rate   float64 `json:"rate"`
-	Tm     int64   `json:"tm"` //资金费收取时间
+	Symbol   string  `json:"symbol"`
+	Rate     float64 `json:"rate"`
+	Tm       int64   `json:"tm"`                  //资金费收取时间
+	NextRate float64 `json:"next_rate,omitempty"` //下一期预测资金费率, 交易所不提供时为0
+	NextTm   int64   `json:"next_tm,omitempty"`   //下一期资金费收取时间
+	Interval int64   `json:"interval,omitempty"`  //收取间隔(毫秒), 交易所不提供时为0
+	MarkPx   float64 `json:"mark_px,omitempty"`
+	IndexPx  float64 `json:"index_px,omitempty"`
+}
+
+// PriceLimit 限价单的最高买价和最低卖价
+type PriceLimit struct {
+	Symbol  string  `json:"symbol"`
+	BuyLmt  float64 `json:"buy_lmt"`  //最高买价
+	SellLmt float64 `json:"sell_lmt"` //最低卖价
+	Ts      int64   `json:"ts"`
 }
 
 type AssetValuation struct {
@@ -211,3 +372,14 @@ type AssetCurrency struct {
 	DepQuotaFixed   float64 `json:"dep_quota_fixed"`   // 充值固定限额
 	DepQuoteDynamic float64 `json:"dep_quota_dynamic"` // 充值动态限额
 }
+
+// Transfer 同一用户不同账户之间的资金划转
+type Transfer struct {
+	Id     string         `json:"id"`           // 划转ID
+	Ccy    string         `json:"ccy"`          // 币种
+	Amount float64        `json:"amount"`       // 划转数量
+	From   AccountType    `json:"from"`         // 转出账户
+	To     AccountType    `json:"to"`           // 转入账户
+	Status TransferStatus `json:"status"`       // 划转状态
+	Ts     int64          `json:"ts,omitempty"` // 划转时间，Unix时间戳的毫秒数格式
+}
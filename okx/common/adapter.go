@@ -1,8 +1,13 @@
 package common
 
 import (
+	"fmt"
 	"github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/util"
+	"github.com/spf13/cast"
 	"net/url"
+	"slices"
+	"strings"
 )
 
 func AdaptKlinePeriodToSymbol(period model.KlinePeriod) string {
@@ -48,6 +53,17 @@ func adaptOrderSideToSym(s model.OrderSide) (side, posSide string) {
 	return "", ""
 }
 
+// AdaptPosSideToSym 仓位方向, 单向持仓为net
+func AdaptPosSideToSym(posSide model.OrderSide) string {
+	switch posSide {
+	case model.Futures_OpenBuy, model.Futures_CloseBuy:
+		return "long"
+	case model.Futures_OpenSell, model.Futures_CloseSell:
+		return "short"
+	}
+	return "net"
+}
+
 func adaptOrderTypeToSym(ty model.OrderType) string {
 	switch ty {
 	case model.OrderType_Limit:
@@ -107,11 +123,181 @@ func adaptSymToOrderStatus(st string) model.OrderStatus {
 	}
 }
 
-func AdaptQtyOrPricePrecision(sz string) int {
-	if sz == "1" {
+func adaptSymToAlgoOrderStatus(st string) model.OrderStatus {
+	switch st {
+	case "live", "pause":
+		return model.OrderStatus_Pending
+	case "partially_effective":
+		return model.OrderStatus_PartFinished
+	case "effective":
+		return model.OrderStatus_Finished
+	case "canceled":
+		return model.OrderStatus_Canceled
+	case "order_failed":
+		return model.OrderStatus_Rejected
+	default:
+		return model.OrderStatus(-1)
+	}
+}
+
+// adaptSymToAlgoOrdPx 委托价格-1为市价
+func adaptSymToAlgoOrdPx(px string) float64 {
+	if px == "" || px == "-1" {
 		return 0
 	}
-	return len(sz) - 2
+	return cast.ToFloat64(px)
+}
+
+// adaptSymToAlgoOrderTy 单向止盈止损(conditional)时, 将委托价格和触发价格转到Price/TriggerPx
+func adaptSymToAlgoOrderTy(ordType string, ord *model.Order) model.OrderType {
+	switch ordType {
+	case "oco":
+		return model.OrderType_OCO
+	case "move_order_stop":
+		return model.OrderType_TrailingStop
+	case "conditional":
+		if ord.SlTriggerPx > 0 && ord.TpTriggerPx > 0 {
+			return model.OrderType_OCO
+		}
+		if ord.TpTriggerPx > 0 {
+			ord.TriggerPx, ord.Price = ord.TpTriggerPx, ord.TpPx
+			ord.TpTriggerPx, ord.TpPx = 0, 0
+			if ord.Price > 0 {
+				return model.OrderType_TakeProfitLimit
+			}
+			return model.OrderType_TakeProfitMarket
+		}
+		ord.TriggerPx, ord.Price = ord.SlTriggerPx, ord.SlPx
+		ord.SlTriggerPx, ord.SlPx = 0, 0
+		if ord.Price > 0 {
+			return model.OrderType_StopLimit
+		}
+		return model.OrderType_StopMarket
+	}
+	return model.OrderType(ordType)
+}
+
+// adaptSymToInstType 根据instId推断产品类型, 如: BTC-USDT-SWAP, BTC-USD-240329, BTC-USD-240329-60000-C
+func adaptSymToInstType(instId string) string {
+	parts := strings.Split(instId, "-")
+	switch {
+	case len(parts) == 2:
+		return "SPOT"
+	case len(parts) == 3 && parts[2] == "SWAP":
+		return "SWAP"
+	case len(parts) == 3:
+		return "FUTURES"
+	case len(parts) == 5:
+		return "OPTION"
+	}
+	return "SPOT"
+}
+
+// adaptTrades okx按时间降序返回, 转换为升序
+func adaptTrades(trades []model.Trade, pair model.CurrencyPair) []model.Trade {
+	slices.Reverse(trades)
+	for i := range trades {
+		trades[i].Pair = pair
+	}
+	return trades
+}
+
+func AdaptQtyOrPricePrecision(sz string) int {
+	return util.PrecisionOf(sz)
+}
+
+// AdaptOrderOptionParameters 转换类型化的下单参数, 有效方式和只做maker通过ordType实现
+func AdaptOrderOptionParameters(params *url.Values) error {
+	opts, err := util.PopOrderOptions(params)
+	if err != nil {
+		return err
+	}
+
+	ordType := params.Get("ordType")
+	if opts.PostOnly {
+		if ordType != "limit" {
+			return util.UnsupportedOption(model.OKX, "post only with order type %s", ordType)
+		}
+		params.Set("ordType", "post_only")
+	} else if opts.TimeInForce != "" {
+		if ordType != "limit" {
+			return util.UnsupportedOption(model.OKX, "time in force %s with order type %s", opts.TimeInForce, ordType)
+		}
+		params.Set("ordType", strings.ToLower(string(opts.TimeInForce)))
+	}
+
+	if opts.ReduceOnly {
+		params.Set("reduceOnly", "true")
+	}
+
+	if opts.ClosePosition {
+		return util.UnsupportedOption(model.OKX, "close position order")
+	}
+
+	if opts.STPMode != "" {
+		params.Set("stpMode", string(opts.STPMode))
+	}
+
+	if opts.PriceMatch != "" {
+		return util.UnsupportedOption(model.OKX, "price match %s", opts.PriceMatch)
+	}
+
+	return nil
+}
+
+// adaptAlgoOrderOptionParameters 策略委托只支持只减仓和全部平仓(closeFraction=1)
+func adaptAlgoOrderOptionParameters(params *url.Values) error {
+	opts, err := util.PopOrderOptions(params)
+	if err != nil {
+		return err
+	}
+
+	if opts.PostOnly || opts.TimeInForce != "" || opts.STPMode != "" || opts.PriceMatch != "" {
+		return util.UnsupportedOption(model.OKX, "algo order only support reduce only and close position")
+	}
+
+	if opts.ReduceOnly {
+		params.Set("reduceOnly", "true")
+	}
+
+	if opts.ClosePosition {
+		params.Set("closeFraction", "1")
+		params.Del("sz")
+	}
+
+	return nil
+}
+
+// adaptAccountType 统一账户下现货、杠杆、合约共用交易账户(18)
+func adaptAccountType(ty model.AccountType) (string, error) {
+	switch ty {
+	case model.AccountType_Funding:
+		return "6", nil
+	case model.AccountType_Trading, model.AccountType_Spot, model.AccountType_Margin,
+		model.AccountType_UsdtFutures, model.AccountType_CoinFutures:
+		return "18", nil
+	}
+	return "", fmt.Errorf("%s unsupported account type: %s", model.OKX, ty)
+}
+
+func adaptSymToAccountType(sym string) model.AccountType {
+	switch sym {
+	case "6":
+		return model.AccountType_Funding
+	case "18":
+		return model.AccountType_Trading
+	}
+	return model.AccountType(sym)
+}
+
+func adaptSymToTransferStatus(st string) model.TransferStatus {
+	switch st {
+	case "success":
+		return model.TransferStatus_Success
+	case "failed":
+		return model.TransferStatus_Failed
+	}
+	return model.TransferStatus_Pending
 }
 
 func AdaptOrderClientIDOptionParameter(params *url.Values) {
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/options"
+)
+
+// WithEnvironment 模拟盘与实盘REST域名相同, 通过x-simulated-trading header区分, websocket使用wspap域名
+func WithEnvironment(env options.Environment) options.UriOption {
+	return func(c *options.UriOptions) {
+		delete(c.Headers, "x-simulated-trading")
+		switch env {
+		case options.Env_Production:
+			c.Endpoint = "https://www.okx.com"
+			c.WsEndpoint = "wss://ws.okx.com:8443/ws/v5/public"
+			c.WsPrivateEndpoint = "wss://ws.okx.com:8443/ws/v5/private"
+		case options.Env_Testnet:
+			c.Endpoint = "https://www.okx.com"
+			c.WsEndpoint = "wss://wspap.okx.com:8443/ws/v5/public"
+			c.WsPrivateEndpoint = "wss://wspap.okx.com:8443/ws/v5/private"
+			options.WithHeader("x-simulated-trading", "1")(c)
+		case options.Env_AWS:
+			c.Endpoint = "https://aws.okx.com"
+			c.WsEndpoint = "wss://wsaws.okx.com:8443/ws/v5/public"
+			c.WsPrivateEndpoint = "wss://wsaws.okx.com:8443/ws/v5/private"
+		default:
+			logger.Errorf("[okx] unsupported environment: %s", env)
+			c.Endpoint, c.WsEndpoint, c.WsPrivateEndpoint = "", "", ""
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -12,9 +13,14 @@ import (
 	"github.com/shadowors/goex/v2/logger"
 	"github.com/shadowors/goex/v2/model"
 	"github.com/shadowors/goex/v2/options"
+	"github.com/shadowors/goex/v2/signer"
 	"github.com/shadowors/goex/v2/util"
+	"github.com/shadowors/goex/v2/validator"
 )
 
+// maxBatchOrders 批量下单/撤单单次最大订单数
+const maxBatchOrders = 20
+
 type Prv struct {
 	*OKxV5
 	apiOpts options.ApiOptions
@@ -33,26 +39,19 @@ func (prv *Prv) GetAccount(coin string) (map[string]model.Account, []byte, error
 }
 
 func (prv *Prv) CreateOrder(pair model.CurrencyPair, qty, price float64, side model.OrderSide, orderTy model.OrderType, opts ...model.OptionParameter) (*model.Order, []byte, error) {
-	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.NewOrderUri)
-	params := url.Values{}
-
-	params.Set("instId", pair.Symbol)
-	//params.Set("tdMode", "cash")
-	//params.Set("posSide", "")
-	params.Set("ordType", adaptOrderTypeToSym(orderTy))
-	params.Set("px", util.FloatToString(price, pair.PricePrecision))
-	params.Set("sz", util.FloatToString(qty, pair.QtyPrecision))
-
-	side2, posSide := adaptOrderSideToSym(side)
-	params.Set("side", side2)
-	if posSide != "" {
-		params.Set("posSide", posSide)
+	err := validator.Chain(prv.apiOpts.OrderValidators).Validate(&model.OrderRequest{
+		Pair: pair, Qty: qty, Price: price, Side: side, OrderTy: orderTy, Opts: opts})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	util.MergeOptionParams(&params, opts...)
-	AdaptOrderClientIDOptionParameter(&params)
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.NewOrderUri)
+	params, err := orderParams(pair, qty, price, side, orderTy, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	data, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, nil)
+	data, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, prv.orderHeaders())
 	if err != nil {
 		logger.Errorf("[CreateOrder] response body =%s", string(responseBody))
 		return nil, responseBody, err
@@ -73,6 +72,212 @@ func (prv *Prv) CreateOrder(pair model.CurrencyPair, qty, price float64, side mo
 	return ord, responseBody, err
 }
 
+// CreateOrders 批量下单, 每次最多20个订单
+func (prv *Prv) CreateOrders(reqs []model.OrderRequest, opt ...model.OptionParameter) ([]model.OrderResult, []byte, error) {
+	var (
+		results      = make([]model.OrderResult, len(reqs))
+		pending      []int
+		responseBody []byte
+		errs         []error
+	)
+
+	for i := range reqs {
+		err := validator.Chain(prv.apiOpts.OrderValidators).Validate(&reqs[i])
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.NewOrdersUri)
+	for start := 0; start < len(pending); start += maxBatchOrders {
+		chunk := pending[start:min(start+maxBatchOrders, len(pending))]
+
+		items := make([]url.Values, 0, len(chunk))
+		sent := make([]int, 0, len(chunk))
+		for _, i := range chunk {
+			req := reqs[i]
+			params, err := orderParams(req.Pair, req.Qty, req.Price, req.Side, req.OrderTy, append(req.Opts, opt...)...)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			items = append(items, params)
+			sent = append(sent, i)
+		}
+		if chunk = sent; len(chunk) == 0 {
+			continue
+		}
+
+		data, body, err := prv.DoAuthBatchRequest(http.MethodPost, reqUrl, items, prv.orderHeaders())
+		responseBody = body
+		var rets []model.OrderResult
+		if err == nil {
+			rets, err = prv.UnmarshalOpts.CreateOrdersResponseUnmarshaler(data)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			for _, i := range chunk {
+				results[i].Err = err
+			}
+			continue
+		}
+
+		for j, i := range chunk {
+			if j >= len(rets) {
+				results[i].Err = errors.New("missing order result")
+				continue
+			}
+			results[i] = rets[j]
+			if rets[j].Err == nil {
+				req := reqs[i]
+				results[i].Order = &model.Order{
+					Pair:    req.Pair,
+					Id:      rets[j].Id,
+					CId:     rets[j].CId,
+					Price:   req.Price,
+					Qty:     req.Qty,
+					Side:    req.Side,
+					OrderTy: req.OrderTy,
+					Status:  model.OrderStatus_Pending,
+				}
+			}
+		}
+	}
+
+	return results, responseBody, errors.Join(errs...)
+}
+
+// CancelOrders 批量撤单, 每次最多20个订单
+func (prv *Prv) CancelOrders(pair model.CurrencyPair, ids []string, opt ...model.OptionParameter) ([]model.OrderResult, []byte, error) {
+	var (
+		results      = make([]model.OrderResult, len(ids))
+		responseBody []byte
+		errs         []error
+	)
+
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.CancelOrdersUri)
+	for start := 0; start < len(ids); start += maxBatchOrders {
+		end := min(start+maxBatchOrders, len(ids))
+
+		items := make([]url.Values, 0, end-start)
+		for _, id := range ids[start:end] {
+			params := url.Values{}
+			params.Set("instId", pair.Symbol)
+			params.Set("ordId", id)
+			util.MergeOptionParams(&params, opt...)
+			items = append(items, params)
+		}
+
+		data, body, err := prv.DoAuthBatchRequest(http.MethodPost, reqUrl, items, prv.orderHeaders())
+		responseBody = body
+		var rets []model.OrderResult
+		if err == nil {
+			rets, err = prv.UnmarshalOpts.CancelOrdersResponseUnmarshaler(data)
+		}
+
+		for i := start; i < end; i++ {
+			results[i].Id = ids[i]
+			if err != nil {
+				results[i].Err = err
+			} else if i-start < len(rets) {
+				results[i].Err = rets[i-start].Err
+			} else {
+				results[i].Err = errors.New("missing order result")
+			}
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return results, responseBody, errors.Join(errs...)
+}
+
+// AmendOrder 原生改单, 订单ID不变
+func (prv *Prv) AmendOrder(pair model.CurrencyPair, id string, newQty, newPrice float64, opt ...model.OptionParameter) (*model.AmendResult, []byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.AmendOrderUri)
+	params := url.Values{}
+	params.Set("instId", pair.Symbol)
+	params.Set("ordId", id)
+	if newQty > 0 {
+		params.Set("newSz", util.FloatToString(newQty, pair.QtyPrecision))
+	}
+	if newPrice > 0 {
+		params.Set("newPx", util.FloatToString(newPrice, pair.PricePrecision))
+	}
+	util.MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, prv.orderHeaders())
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	ord, err := prv.UnmarshalOpts.AmendOrderResponseUnmarshaler(data)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	//未修改的字段(传0)保持为空, 不覆盖为0
+	ord.Pair = pair
+	if newQty > 0 {
+		ord.Qty = newQty
+	}
+	if newPrice > 0 {
+		ord.Price = newPrice
+	}
+
+	return &model.AmendResult{Mode: model.AmendMode_Native, OrigId: id, Order: ord}, responseBody, nil
+}
+
+// CancelAllOrders 撤销交易对所有挂单.
+// OKX的mass-cancel只支持期权, 这里查询挂单后批量撤单, opt用于查询挂单
+func (prv *Prv) CancelAllOrders(pair model.CurrencyPair, opt ...model.OptionParameter) ([]byte, error) {
+	for {
+		orders, responseBody, err := prv.GetPendingOrders(pair, opt...)
+		if err != nil || len(orders) == 0 {
+			return responseBody, err
+		}
+
+		ids := make([]string, 0, len(orders))
+		for _, ord := range orders {
+			ids = append(ids, ord.Id)
+		}
+
+		results, responseBody, err := prv.CancelOrders(pair, ids)
+		if err != nil {
+			return responseBody, err
+		}
+
+		var errs []error
+		for _, ret := range results {
+			if ret.Err != nil {
+				errs = append(errs, fmt.Errorf("cancel order %s: %w", ret.Id, ret.Err))
+			}
+		}
+		if len(errs) > 0 {
+			return responseBody, errors.Join(errs...)
+		}
+
+		if len(orders) < 100 { //挂单接口每次最多返回100条
+			return responseBody, nil
+		}
+	}
+}
+
+// CancelAllAfter 倒计时全部撤单, 按账户生效(忽略pair), timeout范围10s~120s, 0为取消
+func (prv *Prv) CancelAllAfter(pair model.CurrencyPair, timeout time.Duration, opt ...model.OptionParameter) ([]byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.CancelAllAfterUri)
+	params := url.Values{}
+	params.Set("timeOut", fmt.Sprint(int64(timeout.Seconds())))
+	util.MergeOptionParams(&params, opt...)
+
+	_, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, nil)
+	return responseBody, err
+}
+
 func (prv *Prv) GetOrderInfo(pair model.CurrencyPair, id string, opt ...model.OptionParameter) (*model.Order, []byte, error) {
 	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.GetOrderUri)
 	params := url.Values{}
@@ -136,7 +341,7 @@ func (prv *Prv) CancelOrder(pair model.CurrencyPair, id string, opt ...model.Opt
 	params.Set("ordId", id)
 	util.MergeOptionParams(&params, opt...)
 
-	data, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, nil)
+	data, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, prv.orderHeaders())
 	if data != nil && len(data) > 0 {
 		return responseBody, prv.UnmarshalOpts.CancelOrderResponseUnmarshaler(data)
 	}
@@ -144,6 +349,154 @@ func (prv *Prv) CancelOrder(pair model.CurrencyPair, id string, opt ...model.Opt
 	return responseBody, err
 }
 
+// CreateAlgoOrder 策略委托下单: 止盈止损(conditional)/OCO/追踪止损(move_order_stop).
+// 其他OKX策略类型(如trigger)可直接作为OrderTy传入, 使用TriggerPx和Price
+func (prv *Prv) CreateAlgoOrder(req model.AlgoOrderRequest, opt ...model.OptionParameter) (*model.Order, []byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.NewAlgoOrderUri)
+	params, err := algoOrderParams(req, opt...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, nil)
+	if err != nil {
+		logger.Errorf("[CreateAlgoOrder] response body =%s", string(responseBody))
+		return nil, responseBody, err
+	}
+
+	ord, err := prv.UnmarshalOpts.CreateAlgoOrderResponseUnmarshaler(data)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	ord.Pair = req.Pair
+	ord.Qty = req.Qty
+	ord.Price = req.Price
+	ord.Side = req.Side
+	ord.OrderTy = req.OrderTy
+	ord.TriggerPx = req.TriggerPx
+	ord.CallbackRate = req.CallbackRate
+	ord.TpTriggerPx = req.TpTriggerPx
+	ord.TpPx = req.TpPx
+	ord.SlTriggerPx = req.SlTriggerPx
+	ord.SlPx = req.SlPx
+	ord.Status = model.OrderStatus_Pending
+
+	return ord, responseBody, nil
+}
+
+func (prv *Prv) GetAlgoOrderInfo(pair model.CurrencyPair, id string, opt ...model.OptionParameter) (*model.Order, []byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.GetAlgoOrderUri)
+	params := url.Values{}
+	params.Set("algoId", id)
+	util.MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := prv.DoAuthRequest(http.MethodGet, reqUrl, &params, nil)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	ord, err := prv.UnmarshalOpts.GetAlgoOrderInfoResponseUnmarshaler(data[1 : len(data)-1])
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	ord.Pair = pair
+
+	return ord, responseBody, nil
+}
+
+// GetPendingAlgoOrders 未通过opt指定ordType时, 依次查询止盈止损/OCO, 计划委托和追踪止损
+func (prv *Prv) GetPendingAlgoOrders(pair model.CurrencyPair, opt ...model.OptionParameter) ([]model.Order, []byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.GetPendingAlgoOrdersUri)
+	params := url.Values{}
+	params.Set("instId", pair.Symbol)
+	util.MergeOptionParams(&params, opt...)
+
+	ordTypes := []string{params.Get("ordType")}
+	if ordTypes[0] == "" {
+		ordTypes = []string{"conditional,oco", "trigger", "move_order_stop"}
+	}
+
+	var (
+		orders       []model.Order
+		responseBody []byte
+	)
+	for _, ordType := range ordTypes {
+		params.Set("ordType", ordType)
+		data, body, err := prv.DoAuthRequest(http.MethodGet, reqUrl, &params, nil)
+		responseBody = body
+		if err != nil {
+			return nil, responseBody, err
+		}
+
+		ords, err := prv.UnmarshalOpts.GetPendingAlgoOrdersResponseUnmarshaler(data)
+		if err != nil {
+			return nil, responseBody, err
+		}
+		orders = append(orders, ords...)
+	}
+
+	for i := range orders {
+		orders[i].Pair = pair
+	}
+
+	return orders, responseBody, nil
+}
+
+func (prv *Prv) CancelAlgoOrder(pair model.CurrencyPair, id string, opt ...model.OptionParameter) ([]byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.CancelAlgoOrderUri)
+	params := url.Values{}
+	params.Set("instId", pair.Symbol)
+	params.Set("algoId", id)
+	util.MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := prv.DoAuthBatchRequest(http.MethodPost, reqUrl, []url.Values{params}, nil)
+	if err != nil {
+		return responseBody, err
+	}
+
+	return responseBody, prv.UnmarshalOpts.CancelAlgoOrderResponseUnmarshaler(data)
+}
+
+// GetFills 最近3天的成交明细, since早于3天时使用fills-history(最近3个月), limit最大100
+func (prv *Prv) GetFills(pair model.CurrencyPair, since int64, limit int, opt ...model.OptionParameter) ([]model.Trade, []byte, error) {
+	params := url.Values{}
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.GetFillsUri)
+	if since > 0 && time.Since(time.UnixMilli(since)) > 3*24*time.Hour {
+		reqUrl = fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.GetFillsHistoryUri)
+		params.Set("instType", adaptSymToInstType(pair.Symbol))
+	}
+	if pair.Symbol != "" {
+		params.Set("instId", pair.Symbol)
+	}
+	if since > 0 {
+		params.Set("begin", fmt.Sprint(since))
+	}
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(min(limit, 100)))
+	}
+	util.MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := prv.DoAuthRequest(http.MethodGet, reqUrl, &params, nil)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	fills, err := prv.UnmarshalOpts.GetFillsResponseUnmarshaler(data)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	if pair.Symbol != "" {
+		for i := range fills {
+			fills[i].Pair = pair
+		}
+	}
+
+	return fills, responseBody, nil
+}
+
 // GetAssetValuation 获取资产估值
 // currency: 币种(USD、USDT、BTC等)，如果为空字符串，则默认使用账户设置的币种
 func (prv *Prv) GetAssetValuation(currency string) (*model.AssetValuation, []byte, error) {
@@ -215,18 +568,86 @@ func (prv *Prv) GetAssetCurrencies(currency string) ([]model.AssetCurrency, []by
 	return currencies, responseBody, err
 }
 
-func (prv *Prv) DoSignParam(httpMethod, apiUri, apiSecret, reqBody string) (signStr, timestamp string) {
-	timestamp = time.Now().UTC().Format("2006-01-02T15:04:05.000Z") //iso time style
+// Transfer 资金账户与交易账户之间划转, 统一账户下现货/杠杆/合约均为交易账户
+func (prv *Prv) Transfer(ccy string, amount float64, from, to model.AccountType, opt ...model.OptionParameter) (*model.Transfer, []byte, error) {
+	fromSym, err := adaptAccountType(from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toSym, err := adaptAccountType(to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if fromSym == toSym {
+		return nil, nil, fmt.Errorf("%s and %s are the same account in okx", from, to)
+	}
+
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.TransferUri)
+	params := url.Values{}
+	params.Set("ccy", ccy)
+	params.Set("amt", util.FloatToString(amount, 8))
+	params.Set("from", fromSym)
+	params.Set("to", toSym)
+	params.Set("type", "0") //0: 账户内划转
+	util.MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, nil)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	transfer, err := prv.UnmarshalOpts.TransferResponseUnmarshaler(data)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	transfer.Ccy, transfer.Amount, transfer.From, transfer.To = ccy, amount, from, to
+
+	return transfer, responseBody, nil
+}
+
+// GetTransfer 查询划转状态, 忽略from/to
+func (prv *Prv) GetTransfer(id string, from, to model.AccountType, opt ...model.OptionParameter) (*model.Transfer, []byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.UriOpts.Endpoint, prv.UriOpts.GetTransferUri)
+	params := url.Values{}
+	params.Set("transId", id)
+	util.MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := prv.DoAuthRequest(http.MethodGet, reqUrl, &params, nil)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	transfers, err := prv.UnmarshalOpts.GetTransfersResponseUnmarshaler(data)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	for _, transfer := range transfers {
+		if transfer.Id == id {
+			return &transfer, responseBody, nil
+		}
+	}
+
+	return nil, responseBody, fmt.Errorf("transfer %s not found", id)
+}
+
+// DoSignParam 默认使用Secret做HMAC-SHA256签名, 设置了ApiOptions.Signer时使用自定义签名
+func (prv *Prv) DoSignParam(httpMethod, apiUri, reqBody string) (signStr, timestamp string, err error) {
+	timestamp = prv.apiOpts.Now().UTC().Format("2006-01-02T15:04:05.000Z") //iso time style
 	payload := fmt.Sprintf("%s%s%s%s", timestamp, strings.ToUpper(httpMethod), apiUri, reqBody)
-	signStr, _ = util.HmacSHA256Base64Sign(apiSecret, payload)
+	s := prv.apiOpts.Signer
+	if s == nil {
+		s = signer.NewHmacSHA256Base64(prv.apiOpts.Secret)
+	}
+	signStr, err = s.Sign(payload)
 	return
 }
 
 func (prv *Prv) DoAuthRequest(httpMethod, reqUrl string, params *url.Values, headers map[string]string) ([]byte, []byte, error) {
-	var (
-		reqBodyStr string
-		reqUri     string
-	)
+	var reqBodyStr string
 
 	if http.MethodGet == httpMethod {
 		reqUrl += "?" + params.Encode()
@@ -238,18 +659,54 @@ func (prv *Prv) DoAuthRequest(httpMethod, reqUrl string, params *url.Values, hea
 		reqBodyStr = string(reqBody)
 	}
 
+	return prv.doAuthRequest(httpMethod, reqUrl, reqBodyStr, headers, false)
+}
+
+// DoAuthBatchRequest 批量接口, 请求体为json数组.
+// 部分或全部订单失败(code=1/2)时仍返回data, 由调用方根据每个订单的sCode判断结果
+func (prv *Prv) DoAuthBatchRequest(httpMethod, reqUrl string, items []url.Values, headers map[string]string) ([]byte, []byte, error) {
+	var reqBody = make([]json.RawMessage, 0, len(items))
+	for _, params := range items {
+		params.Set("tag", "86d4a3bf87bcBCDE")
+		item, _ := util.ValuesToJson(params)
+		reqBody = append(reqBody, item)
+	}
+	reqBodyData, _ := json.Marshal(reqBody)
+	return prv.doAuthRequest(httpMethod, reqUrl, string(reqBodyData), headers, true)
+}
+
+// orderHeaders 下单/改单/撤单请求的有效截止时间(expTime), 未设置RecvWindow时为空
+func (prv *Prv) orderHeaders() map[string]string {
+	if prv.apiOpts.RecvWindow <= 0 {
+		return nil
+	}
+	return map[string]string{"expTime": fmt.Sprint(prv.apiOpts.Now().Add(prv.apiOpts.RecvWindow).UnixMilli())}
+}
+
+func (prv *Prv) doAuthRequest(httpMethod, reqUrl, reqBodyStr string, extHeaders map[string]string, batch bool) ([]byte, []byte, error) {
+	var reqUri string
+
 	_url, _ := url.Parse(reqUrl)
 	reqUri = _url.RequestURI()
-	signStr, timestamp := prv.DoSignParam(httpMethod, reqUri, prv.apiOpts.Secret, reqBodyStr)
+	signStr, timestamp, err := prv.DoSignParam(httpMethod, reqUri, reqBodyStr)
+	if err != nil {
+		return nil, nil, err
+	}
 	logger.Debugf("[DoAuthRequest] sign base64: %s, timestamp: %s", signStr, timestamp)
 
-	headers = map[string]string{
+	headers := map[string]string{
 		"Content-Type": "application/json; charset=UTF-8",
 		//"Accept":               "application/json",
 		"OK-ACCESS-KEY":        prv.apiOpts.Key,
 		"OK-ACCESS-PASSPHRASE": prv.apiOpts.Passphrase,
 		"OK-ACCESS-SIGN":       signStr,
 		"OK-ACCESS-TIMESTAMP":  timestamp}
+	for k, v := range prv.UriOpts.Headers {
+		headers[k] = v
+	}
+	for k, v := range extHeaders {
+		headers[k] = v
+	}
 
 	respBody, err := httpcli.Cli.DoRequest(httpMethod, reqUrl, reqBodyStr, headers)
 	if err != nil {
@@ -263,6 +720,10 @@ func (prv *Prv) DoAuthRequest(httpMethod, reqUrl string, params *url.Values, hea
 		return nil, respBody, err
 	}
 
+	if batch && (baseResp.Code == 1 || baseResp.Code == 2) && len(baseResp.Data) > 2 {
+		return baseResp.Data, respBody, nil
+	}
+
 	if baseResp.Code != 0 {
 		var errData []ErrorResponseData
 		err = prv.OKxV5.UnmarshalOpts.ResponseUnmarshaler(baseResp.Data, &errData)
@@ -279,8 +740,95 @@ func (prv *Prv) DoAuthRequest(httpMethod, reqUrl string, params *url.Values, hea
 	return baseResp.Data, respBody, nil
 }
 
+func orderParams(pair model.CurrencyPair, qty, price float64, side model.OrderSide, orderTy model.OrderType, opts ...model.OptionParameter) (url.Values, error) {
+	params := url.Values{}
+
+	params.Set("instId", pair.Symbol)
+	//params.Set("tdMode", "cash")
+	//params.Set("posSide", "")
+	params.Set("ordType", adaptOrderTypeToSym(orderTy))
+	params.Set("px", util.FloatToString(price, pair.PricePrecision))
+	params.Set("sz", util.FloatToString(qty, pair.QtyPrecision))
+
+	side2, posSide := adaptOrderSideToSym(side)
+	params.Set("side", side2)
+	if posSide != "" {
+		params.Set("posSide", posSide)
+	}
+
+	util.MergeOptionParams(&params, opts...)
+	AdaptOrderClientIDOptionParameter(&params)
+
+	return params, AdaptOrderOptionParameters(&params)
+}
+
+// algoOrderParams 委托价格为0时传-1(市价)
+func algoOrderParams(req model.AlgoOrderRequest, opts ...model.OptionParameter) (url.Values, error) {
+	params := url.Values{}
+
+	params.Set("instId", req.Pair.Symbol)
+	params.Set("sz", util.FloatToString(req.Qty, req.Pair.QtyPrecision))
+
+	side, posSide := adaptOrderSideToSym(req.Side)
+	params.Set("side", side)
+	if posSide != "" {
+		params.Set("posSide", posSide)
+	}
+
+	ordPx := func(px float64) string {
+		if px <= 0 {
+			return "-1"
+		}
+		return util.FloatToString(px, req.Pair.PricePrecision)
+	}
+
+	switch req.OrderTy {
+	case model.OrderType_StopMarket, model.OrderType_StopLimit:
+		params.Set("ordType", "conditional")
+		params.Set("slTriggerPx", util.FloatToString(req.TriggerPx, req.Pair.PricePrecision))
+		if req.OrderTy == model.OrderType_StopLimit {
+			params.Set("slOrdPx", ordPx(req.Price))
+		} else {
+			params.Set("slOrdPx", "-1")
+		}
+	case model.OrderType_TakeProfitMarket, model.OrderType_TakeProfitLimit:
+		params.Set("ordType", "conditional")
+		params.Set("tpTriggerPx", util.FloatToString(req.TriggerPx, req.Pair.PricePrecision))
+		if req.OrderTy == model.OrderType_TakeProfitLimit {
+			params.Set("tpOrdPx", ordPx(req.Price))
+		} else {
+			params.Set("tpOrdPx", "-1")
+		}
+	case model.OrderType_TrailingStop:
+		params.Set("ordType", "move_order_stop")
+		params.Set("callbackRatio", util.FloatToString(req.CallbackRate, 4))
+		if req.TriggerPx > 0 {
+			params.Set("activePx", util.FloatToString(req.TriggerPx, req.Pair.PricePrecision))
+		}
+	case model.OrderType_OCO:
+		params.Set("ordType", "oco")
+		params.Set("tpTriggerPx", util.FloatToString(req.TpTriggerPx, req.Pair.PricePrecision))
+		params.Set("tpOrdPx", ordPx(req.TpPx))
+		params.Set("slTriggerPx", util.FloatToString(req.SlTriggerPx, req.Pair.PricePrecision))
+		params.Set("slOrdPx", ordPx(req.SlPx))
+	default:
+		params.Set("ordType", string(req.OrderTy))
+		params.Set("triggerPx", util.FloatToString(req.TriggerPx, req.Pair.PricePrecision))
+		params.Set("orderPx", ordPx(req.Price))
+	}
+
+	util.MergeOptionParams(&params, append(req.Opts, opts...)...)
+	if cid := params.Get(model.Order_Client_ID__Opt_Key); cid != "" {
+		params.Set("algoClId", cid)
+		params.Del(model.Order_Client_ID__Opt_Key)
+	}
+
+	return params, adaptAlgoOrderOptionParameters(&params)
+}
+
 func NewPrvApi(opts ...options.ApiOption) *Prv {
 	var api = new(Prv)
+	api.apiOpts.OrderValidators = validator.Defaults()
 	for _, opt := range opts {
 		opt(&api.apiOpts)
 	}
@@ -14,6 +14,17 @@ func (okx *OKxV5) GetName() string {
 	return "okx.com"
 }
 
+// GetServerTime 服务器时间(毫秒)
+func (okx *OKxV5) GetServerTime() (int64, []byte, error) {
+	data, responseBody, err := okx.DoNoAuthRequest(http.MethodGet, okx.UriOpts.Endpoint+okx.UriOpts.GetServerTimeUri, &url.Values{})
+	if err != nil {
+		return 0, responseBody, err
+	}
+
+	ts, err := okx.UnmarshalOpts.GetServerTimeResponseUnmarshaler(data)
+	return ts, responseBody, err
+}
+
 func (okx *OKxV5) GetDepth(pair CurrencyPair, size int, opt ...OptionParameter) (*Depth, []byte, error) {
 	params := url.Values{}
 	params.Set("instId", pair.Symbol)
@@ -54,6 +65,25 @@ func (okx *OKxV5) GetTicker(pair CurrencyPair, opt ...OptionParameter) (*Ticker,
 	return tk, responseBody, err
 }
 
+// GetTickers instType: SPOT,SWAP,FUTURES,OPTION
+func (okx *OKxV5) GetTickers(instType string, opt ...OptionParameter) (map[string]Ticker, []byte, error) {
+	params := url.Values{}
+	params.Set("instType", instType)
+	MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := okx.DoNoAuthRequest(http.MethodGet, okx.UriOpts.Endpoint+okx.UriOpts.GetTickersUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	tickers, err := okx.UnmarshalOpts.GetTickersResponseUnmarshaler(data)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	return tickers, responseBody, nil
+}
+
 func (okx *OKxV5) GetKline(pair CurrencyPair, period KlinePeriod, opt ...OptionParameter) ([]Kline, []byte, error) {
 	reqUrl := fmt.Sprintf("%s%s", okx.UriOpts.Endpoint, okx.UriOpts.KlineUri)
 	param := url.Values{}
@@ -70,6 +100,54 @@ func (okx *OKxV5) GetKline(pair CurrencyPair, period KlinePeriod, opt ...OptionP
 	return klines, responseBody, err
 }
 
+// GetTrades 最近成交, 按时间升序, limit最大500
+func (okx *OKxV5) GetTrades(pair CurrencyPair, limit int, opt ...OptionParameter) ([]Trade, []byte, error) {
+	params := url.Values{}
+	params.Set("instId", pair.Symbol)
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(min(limit, 500)))
+	}
+	MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := okx.DoNoAuthRequest(http.MethodGet, okx.UriOpts.Endpoint+okx.UriOpts.GetTradesUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	trades, err := okx.UnmarshalOpts.GetTradesResponseUnmarshaler(data)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	return adaptTrades(trades, pair), responseBody, nil
+}
+
+// GetHistoryTrades 按成交ID向前翻页, limit最大100
+func (okx *OKxV5) GetHistoryTrades(pair CurrencyPair, afterId string, limit int, opt ...OptionParameter) ([]Trade, []byte, error) {
+	params := url.Values{}
+	params.Set("instId", pair.Symbol)
+	params.Set("type", "1")
+	if afterId != "" {
+		params.Set("after", afterId)
+	}
+	if limit > 0 {
+		params.Set("limit", fmt.Sprint(min(limit, 100)))
+	}
+	MergeOptionParams(&params, opt...)
+
+	data, responseBody, err := okx.DoNoAuthRequest(http.MethodGet, okx.UriOpts.Endpoint+okx.UriOpts.GetHistoryTradesUri, &params)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	trades, err := okx.UnmarshalOpts.GetHistoryTradesResponseUnmarshaler(data)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	return adaptTrades(trades, pair), responseBody, nil
+}
+
 func (okx *OKxV5) GetExchangeInfo(instType string, opt ...OptionParameter) (map[string]CurrencyPair, []byte, error) {
 	reqUrl := fmt.Sprintf("%s%s", okx.UriOpts.Endpoint, okx.UriOpts.GetExchangeInfoUri)
 	param := url.Values{}
@@ -116,13 +194,27 @@ func (okx *OKxV5) GetFundingRateHistory(pair CurrencyPair, limit int, opts ...Op
 	return rates, nil, err
 }
 
+// GetPriceLimit 获取限价单的最高买价和最低卖价, 可配合validator.PriceLimit做下单前校验
+func (okx *OKxV5) GetPriceLimit(pair CurrencyPair, opts ...OptionParameter) (*PriceLimit, []byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", okx.UriOpts.Endpoint, okx.UriOpts.GetPriceLimitUri)
+	param := url.Values{}
+	param.Set("instId", pair.Symbol)
+	MergeOptionParams(&param, opts...)
+	data, responseBody, err := okx.DoNoAuthRequest(http.MethodGet, reqUrl, &param)
+	if err != nil {
+		return nil, responseBody, err
+	}
+	lmt, err := okx.UnmarshalOpts.GetPriceLimitResponseUnmarshaler(data)
+	return lmt, responseBody, err
+}
+
 func (okx *OKxV5) DoNoAuthRequest(httpMethod, reqUrl string, params *url.Values) ([]byte, []byte, error) {
 	reqBody := ""
 	if http.MethodGet == httpMethod {
 		reqUrl += "?" + params.Encode()
 	}
 
-	responseBody, err := Cli.DoRequest(httpMethod, reqUrl, reqBody, nil)
+	responseBody, err := Cli.DoRequest(httpMethod, reqUrl, reqBody, okx.UriOpts.Headers)
 	if err != nil {
 		return nil, responseBody, err
 	}
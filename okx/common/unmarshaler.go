@@ -62,30 +62,10 @@ func (un *RespUnmarshaler) unmarshalDepthItem(data []byte) (DepthItems, error) {
 func (un *RespUnmarshaler) UnmarshalTicker(data []byte) (*Ticker, error) {
 	var tk = &Ticker{}
 
-	var open float64
 	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
-		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
-			valStr := string(val)
-			switch string(key) {
-			case "last":
-				tk.Last = cast.ToFloat64(valStr)
-			case "askPx":
-				tk.Sell = cast.ToFloat64(valStr)
-			case "bidPx":
-				tk.Buy = cast.ToFloat64(valStr)
-			case "vol24h":
-				tk.Vol = cast.ToFloat64(valStr)
-			case "high24h":
-				tk.High = cast.ToFloat64(valStr)
-			case "low24h":
-				tk.Low = cast.ToFloat64(valStr)
-			case "ts":
-				tk.Timestamp = cast.ToInt64(valStr)
-			case "open24h":
-				open = cast.ToFloat64(valStr)
-			}
-			return nil
-		})
+		if t, er := unmarshalTicker(value); er == nil {
+			tk = t
+		}
 	})
 
 	if err != nil {
@@ -93,7 +73,75 @@ func (un *RespUnmarshaler) UnmarshalTicker(data []byte) (*Ticker, error) {
 		return nil, err
 	}
 
-	tk.Percent = (tk.Last - open) / open * 100
+	return tk, nil
+}
+
+// UnmarshalGetTickersResponse key为instId
+func (un *RespUnmarshaler) UnmarshalGetTickersResponse(data []byte) (map[string]Ticker, error) {
+	var tickers = make(map[string]Ticker, 64)
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		tk, er := unmarshalTicker(value)
+		if er != nil {
+			logger.Errorf("[UnmarshalGetTickersResponse] %s", er.Error())
+			return
+		}
+		tickers[tk.Pair.Symbol] = *tk
+	})
+
+	return tickers, err
+}
+
+// unmarshalTicker 币币volCcy24h为计价币成交额, 合约为交易币数量, 按最新价折算
+func unmarshalTicker(data []byte) (*Ticker, error) {
+	var (
+		tk           = &Ticker{}
+		open, volCcy float64
+	)
+
+	err := jsonparser.ObjectEach(data, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+		valStr := string(val)
+		switch string(key) {
+		case "instId":
+			tk.Pair.Symbol = valStr
+		case "last":
+			tk.Last = cast.ToFloat64(valStr)
+		case "askPx":
+			tk.Sell = cast.ToFloat64(valStr)
+		case "askSz":
+			tk.SellQty = cast.ToFloat64(valStr)
+		case "bidPx":
+			tk.Buy = cast.ToFloat64(valStr)
+		case "bidSz":
+			tk.BuyQty = cast.ToFloat64(valStr)
+		case "vol24h":
+			tk.Vol = cast.ToFloat64(valStr)
+		case "volCcy24h":
+			volCcy = cast.ToFloat64(valStr)
+		case "high24h":
+			tk.High = cast.ToFloat64(valStr)
+		case "low24h":
+			tk.Low = cast.ToFloat64(valStr)
+		case "ts":
+			tk.Timestamp = cast.ToInt64(valStr)
+		case "open24h":
+			open = cast.ToFloat64(valStr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if adaptSymToInstType(tk.Pair.Symbol) == "SPOT" {
+		tk.QuoteVol = volCcy
+	} else {
+		tk.QuoteVol = volCcy * tk.Last
+	}
+
+	if open > 0 {
+		tk.Percent = (tk.Last - open) / open * 100
+	}
 
 	return tk, nil
 }
@@ -297,6 +345,238 @@ func (un *RespUnmarshaler) UnmarshalCancelOrderResponse(data []byte) error {
 	return errors.New(string(data))
 }
 
+// UnmarshalBatchOrdersResponse 批量下单/撤单返回, sCode非0为单个订单失败
+func (un *RespUnmarshaler) UnmarshalBatchOrdersResponse(data []byte) ([]OrderResult, error) {
+	var results []OrderResult
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			ret         OrderResult
+			sCode, sMsg string
+		)
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "ordId":
+				ret.Id = valStr
+			case "clOrdId":
+				ret.CId = valStr
+			case "sCode":
+				sCode = valStr
+			case "sMsg":
+				sMsg = valStr
+			}
+			return nil
+		})
+		if cast.ToInt64(sCode) != 0 {
+			ret.Err = fmt.Errorf("%s: %s", sCode, sMsg)
+		}
+		results = append(results, ret)
+	})
+	return results, err
+}
+
+func (un *RespUnmarshaler) UnmarshalAmendOrderResponse(data []byte) (*Order, error) {
+	rets, err := un.UnmarshalBatchOrdersResponse(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rets) == 0 {
+		return nil, errors.New(string(data))
+	}
+	if rets[0].Err != nil {
+		return nil, rets[0].Err
+	}
+	return &Order{Id: rets[0].Id, CId: rets[0].CId}, nil
+}
+
+func (un *RespUnmarshaler) UnmarshalCreateAlgoOrderResponse(data []byte) (*Order, error) {
+	var ord = new(Order)
+	err := jsonparser.ObjectEach(data[1:len(data)-1], func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		valStr := string(value)
+		switch string(key) {
+		case "algoId":
+			ord.Id = valStr
+		case "algoClId":
+			ord.CId = valStr
+		}
+		return nil
+	})
+	return ord, err
+}
+
+func (un *RespUnmarshaler) UnmarshalGetPendingAlgoOrdersResponse(data []byte) ([]Order, error) {
+	var orders []Order
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		ord, err := un.UnmarshalGetAlgoOrderInfoResponse(value)
+		if err != nil {
+			return
+		}
+		orders = append(orders, *ord)
+	})
+	return orders, err
+}
+
+// UnmarshalGetAlgoOrderInfoResponse 策略委托订单, 委托价格-1为市价(转换为0)
+func (un *RespUnmarshaler) UnmarshalGetAlgoOrderInfoResponse(data []byte) (ord *Order, err error) {
+	var (
+		side, posSide, ordType string
+		tpPx, slPx, ordPx      string
+		utime                  int64
+	)
+	ord = new(Order)
+
+	err = jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		valStr := string(value)
+		switch string(key) {
+		case "algoId":
+			ord.Id = valStr
+		case "algoClId":
+			ord.CId = valStr
+		case "sz":
+			ord.Qty = cast.ToFloat64(valStr)
+		case "actualSz":
+			ord.ExecutedQty = cast.ToFloat64(valStr)
+		case "actualPx":
+			ord.PriceAvg = cast.ToFloat64(valStr)
+		case "side":
+			side = valStr
+		case "posSide":
+			posSide = valStr
+		case "ordType":
+			ordType = valStr
+		case "state":
+			ord.Status = adaptSymToAlgoOrderStatus(valStr)
+		case "triggerPx":
+			ord.TriggerPx = cast.ToFloat64(valStr)
+		case "activePx":
+			ord.TriggerPx = cast.ToFloat64(valStr)
+		case "ordPx":
+			ordPx = valStr
+		case "callbackRatio":
+			ord.CallbackRate = cast.ToFloat64(valStr)
+		case "tpTriggerPx":
+			ord.TpTriggerPx = cast.ToFloat64(valStr)
+		case "tpOrdPx":
+			tpPx = valStr
+		case "slTriggerPx":
+			ord.SlTriggerPx = cast.ToFloat64(valStr)
+		case "slOrdPx":
+			slPx = valStr
+		case "cTime":
+			ord.CreatedAt = cast.ToInt64(valStr)
+		case "uTime":
+			utime = cast.ToInt64(valStr)
+		}
+		return nil
+	})
+
+	ord.Side = adaptSymToOrderSide(side, posSide)
+	ord.TpPx = adaptSymToAlgoOrdPx(tpPx)
+	ord.SlPx = adaptSymToAlgoOrdPx(slPx)
+	ord.Price = adaptSymToAlgoOrdPx(ordPx)
+	ord.OrderTy = adaptSymToAlgoOrderTy(ordType, ord)
+
+	switch ord.Status {
+	case OrderStatus_Canceled:
+		ord.CanceledAt = utime
+	case OrderStatus_Finished:
+		ord.FinishedAt = utime
+	}
+
+	return
+}
+
+// UnmarshalGetFillsResponse fee为负数表示扣除, 转换为正数
+func (un *RespUnmarshaler) UnmarshalGetFillsResponse(data []byte) ([]Trade, error) {
+	var fills []Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var (
+			fill          Trade
+			side, posSide string
+		)
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "instId":
+				fill.Pair.Symbol = valStr
+			case "tradeId":
+				fill.Id = valStr
+			case "ordId":
+				fill.OrderId = valStr
+			case "fillPx":
+				fill.Price = cast.ToFloat64(valStr)
+			case "fillSz":
+				fill.Qty = cast.ToFloat64(valStr)
+			case "fee":
+				fill.Fee = -cast.ToFloat64(valStr)
+			case "feeCcy":
+				fill.FeeCcy = valStr
+			case "execType":
+				fill.IsMaker = valStr == "M"
+			case "ts":
+				fill.Ts = cast.ToInt64(valStr)
+			case "side":
+				side = valStr
+			case "posSide":
+				posSide = valStr
+			}
+			return nil
+		})
+		fill.Side = adaptSymToOrderSide(side, posSide)
+		fills = append(fills, fill)
+	})
+	return fills, err
+}
+
+func (un *RespUnmarshaler) UnmarshalGetServerTimeResponse(data []byte) (int64, error) {
+	var ts int64
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		tsStr, _ := jsonparser.GetString(value, "ts")
+		ts = cast.ToInt64(tsStr)
+	})
+	return ts, err
+}
+
+// UnmarshalGetTradesResponse side为主动成交(taker)方向
+func (un *RespUnmarshaler) UnmarshalGetTradesResponse(data []byte) ([]Trade, error) {
+	var trades []Trade
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var trade Trade
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "instId":
+				trade.Pair.Symbol = valStr
+			case "tradeId":
+				trade.Id = valStr
+			case "px":
+				trade.Price = cast.ToFloat64(valStr)
+			case "sz":
+				trade.Qty = cast.ToFloat64(valStr)
+			case "side":
+				trade.Side = adaptSymToOrderSide(valStr, "")
+			case "ts":
+				trade.Ts = cast.ToInt64(valStr)
+			}
+			return nil
+		})
+		trades = append(trades, trade)
+	})
+	return trades, err
+}
+
+// UnmarshalGetLeverageResponse 双向持仓逐仓模式下多空分别返回, 取较大的杠杆倍数
+func (un *RespUnmarshaler) UnmarshalGetLeverageResponse(data []byte) (float64, error) {
+	var lever float64
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		leverStr, _ := jsonparser.GetString(value, "lever")
+		if v := cast.ToFloat64(leverStr); v > lever {
+			lever = v
+		}
+	})
+	return lever, err
+}
+
 func (un *RespUnmarshaler) UnmarshalGetPositionsResponse(data []byte) ([]FuturesPosition, error) {
 	var (
 		positions []FuturesPosition
@@ -327,6 +607,16 @@ func (un *RespUnmarshaler) UnmarshalGetPositionsResponse(data []byte) ([]Futures
 				pos.UplRatio = cast.ToFloat64(valStr)
 			case "lever":
 				pos.Lever = cast.ToFloat64(valStr)
+			case "instId":
+				pos.Pair.Symbol = valStr
+			case "liqPx":
+				pos.LiqPx = cast.ToFloat64(valStr)
+			case "imr", "margin":
+				if v := cast.ToFloat64(valStr); v > 0 {
+					pos.Margin = v
+				}
+			case "realizedPnl":
+				pos.Rpl = cast.ToFloat64(valStr)
 			}
 			return nil
 		})
@@ -360,9 +650,15 @@ func (un *RespUnmarshaler) UnmarshalGetExchangeInfoResponse(data []byte) (map[st
 			case "minSz":
 				currencyPair.MinQty = cast.ToFloat64(valStr)
 			case "tickSz":
+				currencyPair.TickSize = cast.ToFloat64(valStr)
 				currencyPair.PricePrecision = AdaptQtyOrPricePrecision(valStr)
 			case "lotSz":
+				currencyPair.LotSize = cast.ToFloat64(valStr)
 				currencyPair.QtyPrecision = AdaptQtyOrPricePrecision(valStr)
+			case "maxLmtSz":
+				currencyPair.MaxQty = cast.ToFloat64(valStr)
+			case "maxMktSz":
+				currencyPair.MarketQty = cast.ToFloat64(valStr)
 			case "baseCcy":
 				currencyPair.BaseSymbol = valStr
 			case "quoteCcy":
@@ -410,33 +706,72 @@ func (un *RespUnmarshaler) UnmarshalGetExchangeInfoResponse(data []byte) (map[st
 
 func (un *RespUnmarshaler) UnmarshalGetFundingRateResponse(data []byte) (*FundingRate, error) {
 	var rate FundingRate
-	err := jsonparser.ObjectEach(data[1:len(data)-1], func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
-		switch string(key) {
-		case "fundingRate":
-			rate.Rate = cast.ToFloat64(string(value))
-		case "fundingTime":
-			rate.Tm = cast.ToInt64(string(value))
-		}
-		return nil
-	})
+	err := unmarshalFundingRate(data[1:len(data)-1], &rate)
 	if err != nil {
 		return nil, err
 	}
 	return &rate, nil
 }
 
+// UnmarshalGetFundingRateHistoryResponse 按时间降序
 func (un *RespUnmarshaler) UnmarshalGetFundingRateHistoryResponse(data []byte) ([]FundingRate, error) {
 	var fundingRates []FundingRate
 	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
-		fundingRate, err := un.UnmarshalGetFundingRateResponse(value)
-		if err != nil {
+		var rate FundingRate
+		if unmarshalFundingRate(value, &rate) != nil {
 			return
 		}
-		fundingRates = append(fundingRates, *fundingRate)
+		fundingRates = append(fundingRates, rate)
 	})
 	return fundingRates, err
 }
 
+// unmarshalFundingRate nextFundingRate为预测费率, 部分合约为空
+func unmarshalFundingRate(data []byte, rate *FundingRate) error {
+	err := jsonparser.ObjectEach(data, func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		valStr := string(value)
+		switch string(key) {
+		case "instId":
+			rate.Symbol = valStr
+		case "fundingRate":
+			rate.Rate = cast.ToFloat64(valStr)
+		case "fundingTime":
+			rate.Tm = cast.ToInt64(valStr)
+		case "nextFundingRate":
+			rate.NextRate = cast.ToFloat64(valStr)
+		case "nextFundingTime":
+			rate.NextTm = cast.ToInt64(valStr)
+		}
+		return nil
+	})
+	if rate.NextTm > rate.Tm && rate.Tm > 0 {
+		rate.Interval = rate.NextTm - rate.Tm
+	}
+	return err
+}
+
+func (un *RespUnmarshaler) UnmarshalGetPriceLimitResponse(data []byte) (*PriceLimit, error) {
+	var lmt PriceLimit
+	err := jsonparser.ObjectEach(data[1:len(data)-1], func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
+		valStr := string(value)
+		switch string(key) {
+		case "instId":
+			lmt.Symbol = valStr
+		case "buyLmt":
+			lmt.BuyLmt = cast.ToFloat64(valStr)
+		case "sellLmt":
+			lmt.SellLmt = cast.ToFloat64(valStr)
+		case "ts":
+			lmt.Ts = cast.ToInt64(valStr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &lmt, nil
+}
+
 func (un *RespUnmarshaler) UnmarshalGetAssetValuationResponse(data []byte) (*AssetValuation, error) {
 	var av = new(AssetValuation)
 	err := jsonparser.ObjectEach(data[1:len(data)-1], func(key []byte, value []byte, dataType jsonparser.ValueType, offset int) error {
@@ -589,3 +924,54 @@ func (un *RespUnmarshaler) UnmarshalGetAssetCurrenciesResponse(data []byte) ([]A
 func (un *RespUnmarshaler) UnmarshalResponse(data []byte, res interface{}) error {
 	return json.Unmarshal(data, res)
 }
+
+// UnmarshalTransferResponse 划转申请已受理, 最终状态需通过transfer-state查询
+func (un *RespUnmarshaler) UnmarshalTransferResponse(data []byte) (*Transfer, error) {
+	transfers, err := un.UnmarshalGetTransfersResponse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transfers) == 0 {
+		return nil, errors.New("empty transfer response")
+	}
+
+	transfer := transfers[0]
+	transfer.Status = TransferStatus_Pending
+
+	return &transfer, nil
+}
+
+func (un *RespUnmarshaler) UnmarshalGetTransfersResponse(data []byte) ([]Transfer, error) {
+	var transfers []Transfer
+
+	_, err := jsonparser.ArrayEach(data, func(value []byte, dataType jsonparser.ValueType, offset int, err error) {
+		var transfer Transfer
+		err = jsonparser.ObjectEach(value, func(key []byte, val []byte, dataType jsonparser.ValueType, offset int) error {
+			valStr := string(val)
+			switch string(key) {
+			case "transId":
+				transfer.Id = valStr
+			case "ccy":
+				transfer.Ccy = valStr
+			case "amt":
+				transfer.Amount = cast.ToFloat64(valStr)
+			case "from":
+				transfer.From = adaptSymToAccountType(valStr)
+			case "to":
+				transfer.To = adaptSymToAccountType(valStr)
+			case "state":
+				transfer.Status = adaptSymToTransferStatus(valStr)
+			}
+			return nil
+		})
+
+		if err != nil {
+			return
+		}
+
+		transfers = append(transfers, transfer)
+	})
+
+	return transfers, err
+}
@@ -30,6 +30,8 @@ func New() *OKxV5 {
 	f := &OKxV5{
 		UriOpts: UriOptions{
 			Endpoint:                 "https://www.okx.com",
+			WsEndpoint:               "wss://ws.okx.com:8443/ws/v5/public",
+			WsPrivateEndpoint:        "wss://ws.okx.com:8443/ws/v5/private",
 			KlineUri:                 "/api/v5/market/candles",
 			TickerUri:                "/api/v5/market/ticker",
 			DepthUri:                 "/api/v5/market/books",
@@ -47,6 +49,27 @@ func New() *OKxV5 {
 			GetAssetBalancesUri:      "/api/v5/asset/balances",
 			GetAssetBillsUri:         "/api/v5/asset/bills",
 			GetAssetCurrenciesUri:    "/api/v5/asset/currencies",
+			GetPriceLimitUri:         "/api/v5/public/price-limit",
+			NewOrdersUri:             "/api/v5/trade/batch-orders",
+			CancelOrdersUri:          "/api/v5/trade/cancel-batch-orders",
+			AmendOrderUri:            "/api/v5/trade/amend-order",
+			CancelAllAfterUri:        "/api/v5/trade/cancel-all-after",
+			SetLeverageUri:           "/api/v5/account/set-leverage",
+			GetLeverageUri:           "/api/v5/account/leverage-info",
+			SetPositionModeUri:       "/api/v5/account/set-position-mode",
+			AdjustMarginUri:          "/api/v5/account/position/margin-balance",
+			NewAlgoOrderUri:          "/api/v5/trade/order-algo",
+			GetAlgoOrderUri:          "/api/v5/trade/order-algo",
+			GetPendingAlgoOrdersUri:  "/api/v5/trade/orders-algo-pending",
+			CancelAlgoOrderUri:       "/api/v5/trade/cancel-algos",
+			GetFillsUri:              "/api/v5/trade/fills",
+			GetFillsHistoryUri:       "/api/v5/trade/fills-history",
+			GetTradesUri:             "/api/v5/market/trades",
+			GetTickersUri:            "/api/v5/market/tickers",
+			GetServerTimeUri:         "/api/v5/public/time",
+			GetHistoryTradesUri:      "/api/v5/market/history-trades",
+			TransferUri:              "/api/v5/asset/transfer",
+			GetTransferUri:           "/api/v5/asset/transfer-state",
 		},
 		UnmarshalOpts: UnmarshalerOptions{
 			ResponseUnmarshaler:                      unmarshaler.UnmarshalResponse,
@@ -68,6 +91,22 @@ func New() *OKxV5 {
 			GetAssetBalancesResponseUnmarshaler:      unmarshaler.UnmarshalGetAssetBalancesResponse,
 			GetAssetBillsResponseUnmarshaler:         unmarshaler.UnmarshalGetAssetBillsResponse,
 			GetAssetCurrenciesResponseUnmarshaler:    unmarshaler.UnmarshalGetAssetCurrenciesResponse,
+			GetPriceLimitResponseUnmarshaler:         unmarshaler.UnmarshalGetPriceLimitResponse,
+			CreateOrdersResponseUnmarshaler:          unmarshaler.UnmarshalBatchOrdersResponse,
+			CancelOrdersResponseUnmarshaler:          unmarshaler.UnmarshalBatchOrdersResponse,
+			AmendOrderResponseUnmarshaler:            unmarshaler.UnmarshalAmendOrderResponse,
+			GetLeverageResponseUnmarshaler:           unmarshaler.UnmarshalGetLeverageResponse,
+			CreateAlgoOrderResponseUnmarshaler:       unmarshaler.UnmarshalCreateAlgoOrderResponse,
+			GetAlgoOrderInfoResponseUnmarshaler:      unmarshaler.UnmarshalGetAlgoOrderInfoResponse,
+			GetPendingAlgoOrdersResponseUnmarshaler:  unmarshaler.UnmarshalGetPendingAlgoOrdersResponse,
+			CancelAlgoOrderResponseUnmarshaler:       unmarshaler.UnmarshalCancelOrderResponse,
+			GetFillsResponseUnmarshaler:              unmarshaler.UnmarshalGetFillsResponse,
+			GetTradesResponseUnmarshaler:             unmarshaler.UnmarshalGetTradesResponse,
+			GetTickersResponseUnmarshaler:            unmarshaler.UnmarshalGetTickersResponse,
+			GetServerTimeResponseUnmarshaler:         unmarshaler.UnmarshalGetServerTimeResponse,
+			GetHistoryTradesResponseUnmarshaler:      unmarshaler.UnmarshalGetTradesResponse,
+			TransferResponseUnmarshaler:              unmarshaler.UnmarshalTransferResponse,
+			GetTransfersResponseUnmarshaler:          unmarshaler.UnmarshalGetTransfersResponse,
 		},
 	}
 
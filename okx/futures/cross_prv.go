@@ -25,3 +25,23 @@ func (f *CrossPrvApi) CreateOrder(pair CurrencyPair, qty, price float64, side Or
 
 	return f.Prv.CreateOrder(pair, qty, price, side, orderTy, opts...)
 }
+
+func (f *CrossPrvApi) CreateOrders(reqs []OrderRequest, opts ...OptionParameter) ([]OrderResult, []byte, error) {
+	opts = append(opts,
+		OptionParameter{
+			Key:   "tdMode",
+			Value: "cross",
+		})
+
+	return f.Prv.CreateOrders(reqs, opts...)
+}
+
+func (f *CrossPrvApi) CreateAlgoOrder(req AlgoOrderRequest, opts ...OptionParameter) (*Order, []byte, error) {
+	opts = append(opts,
+		OptionParameter{
+			Key:   "tdMode",
+			Value: "cross",
+		})
+
+	return f.Prv.CreateAlgoOrder(req, opts...)
+}
@@ -25,3 +25,23 @@ func (f *IsolatedPrvApi) CreateOrder(pair CurrencyPair, qty, price float64, side
 
 	return f.Prv.CreateOrder(pair, qty, price, side, orderTy, opts...)
 }
+
+func (f *IsolatedPrvApi) CreateOrders(reqs []OrderRequest, opts ...OptionParameter) ([]OrderResult, []byte, error) {
+	opts = append(opts,
+		OptionParameter{
+			Key:   "tdMode",
+			Value: "isolated",
+		})
+
+	return f.Prv.CreateOrders(reqs, opts...)
+}
+
+func (f *IsolatedPrvApi) CreateAlgoOrder(req AlgoOrderRequest, opts ...OptionParameter) (*Order, []byte, error) {
+	opts = append(opts,
+		OptionParameter{
+			Key:   "tdMode",
+			Value: "isolated",
+		})
+
+	return f.Prv.CreateAlgoOrder(req, opts...)
+}
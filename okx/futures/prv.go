@@ -1,11 +1,13 @@
 package futures
 
 import (
+	"errors"
 	"fmt"
 	"github.com/shadowors/goex/v2/model"
 	"github.com/shadowors/goex/v2/okx/common"
 	"github.com/shadowors/goex/v2/options"
 	"github.com/shadowors/goex/v2/util"
+	"math"
 	"net/http"
 	"net/url"
 )
@@ -44,13 +46,20 @@ func (prv *PrvApi) GetFuturesAccount(coin string) (map[string]model.FuturesAccou
 func (prv *PrvApi) GetPositions(pair model.CurrencyPair, opts ...model.OptionParameter) ([]model.FuturesPosition, []byte, error) {
 	reqUrl := fmt.Sprintf("%s%s", prv.OKxV5.UriOpts.Endpoint, prv.OKxV5.UriOpts.GetPositionsUri)
 	params := url.Values{}
-	params.Set("instId", pair.Symbol)
+	if pair.Symbol != "" { //为空时返回所有持仓
+		params.Set("instId", pair.Symbol)
+	}
 	util.MergeOptionParams(&params, opts...)
 	data, responseBody, err := prv.DoAuthRequest(http.MethodGet, reqUrl, &params, nil)
 	if err != nil {
 		return nil, responseBody, err
 	}
 	positions, err := prv.OKxV5.UnmarshalOpts.GetPositionsResponseUnmarshaler(data)
+	if pair.Symbol != "" {
+		for i := range positions {
+			positions[i].Pair = pair
+		}
+	}
 	return positions, responseBody, err
 }
 
@@ -61,3 +70,68 @@ func (prv *PrvApi) GetHistoryOrders(pair model.CurrencyPair, opt ...model.Option
 	})
 	return prv.Prv.GetHistoryOrders(pair, opt...)
 }
+
+func (prv *PrvApi) GetLeverage(pair model.CurrencyPair, marginMode model.MarginMode, opts ...model.OptionParameter) (float64, []byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.OKxV5.UriOpts.Endpoint, prv.OKxV5.UriOpts.GetLeverageUri)
+	params := url.Values{}
+	params.Set("instId", pair.Symbol)
+	params.Set("mgnMode", string(marginMode))
+	util.MergeOptionParams(&params, opts...)
+
+	data, responseBody, err := prv.DoAuthRequest(http.MethodGet, reqUrl, &params, nil)
+	if err != nil {
+		return 0, responseBody, err
+	}
+
+	lever, err := prv.OKxV5.UnmarshalOpts.GetLeverageResponseUnmarshaler(data)
+	return lever, responseBody, err
+}
+
+// SetLeverage 双向持仓逐仓模式下需要通过opts传入posSide(long/short)
+func (prv *PrvApi) SetLeverage(pair model.CurrencyPair, lever float64, marginMode model.MarginMode, opts ...model.OptionParameter) ([]byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.OKxV5.UriOpts.Endpoint, prv.OKxV5.UriOpts.SetLeverageUri)
+	params := url.Values{}
+	params.Set("instId", pair.Symbol)
+	params.Set("lever", util.FloatToString(lever, 2))
+	params.Set("mgnMode", string(marginMode))
+	util.MergeOptionParams(&params, opts...)
+
+	_, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, nil)
+	return responseBody, err
+}
+
+// SetMarginMode OKX下单时通过tdMode指定保证金模式, 请使用Isolated/Cross下单
+func (prv *PrvApi) SetMarginMode(pair model.CurrencyPair, mode model.MarginMode, opts ...model.OptionParameter) ([]byte, error) {
+	return nil, errors.New("okx: margin mode is specified per order by tdMode, use Isolated or Cross api")
+}
+
+func (prv *PrvApi) SetPositionMode(mode model.PositionMode, opts ...model.OptionParameter) ([]byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.OKxV5.UriOpts.Endpoint, prv.OKxV5.UriOpts.SetPositionModeUri)
+	params := url.Values{}
+	if mode == model.PositionMode_Hedge {
+		params.Set("posMode", "long_short_mode")
+	} else {
+		params.Set("posMode", "net_mode")
+	}
+	util.MergeOptionParams(&params, opts...)
+
+	_, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, nil)
+	return responseBody, err
+}
+
+func (prv *PrvApi) AdjustMargin(pair model.CurrencyPair, posSide model.OrderSide, amount float64, opts ...model.OptionParameter) ([]byte, error) {
+	reqUrl := fmt.Sprintf("%s%s", prv.OKxV5.UriOpts.Endpoint, prv.OKxV5.UriOpts.AdjustMarginUri)
+	params := url.Values{}
+	params.Set("instId", pair.Symbol)
+	params.Set("posSide", common.AdaptPosSideToSym(posSide))
+	if amount > 0 {
+		params.Set("type", "add")
+	} else {
+		params.Set("type", "reduce")
+	}
+	params.Set("amt", util.FloatToString(math.Abs(amount), 8))
+	util.MergeOptionParams(&params, opts...)
+
+	_, responseBody, err := prv.DoAuthRequest(http.MethodPost, reqUrl, &params, nil)
+	return responseBody, err
+}
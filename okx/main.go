@@ -4,6 +4,7 @@ import (
 	"github.com/shadowors/goex/v2/okx/common"
 	"github.com/shadowors/goex/v2/okx/futures"
 	"github.com/shadowors/goex/v2/okx/spot"
+	"github.com/shadowors/goex/v2/options"
 )
 
 type OKx struct {
@@ -22,3 +23,11 @@ func New() *OKx {
 		Asset:   okxV5,
 	}
 }
+
+// WithEnvironment 切换实盘/模拟盘/AWS, 同时作用于Spot,Futures,Swap,Asset
+func (o *OKx) WithEnvironment(env options.Environment) *OKx {
+	for _, v5 := range []*common.OKxV5{o.Spot.OKxV5, o.Futures.OKxV5, o.Swap.OKxV5, o.Asset} {
+		v5.WithUriOption(common.WithEnvironment(env))
+	}
+	return o
+}
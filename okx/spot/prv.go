@@ -25,6 +25,16 @@ func (api *PrvApi) CreateOrder(pair CurrencyPair, qty, price float64, side Order
 	return api.Prv.CreateOrder(pair, qty, price, side, orderTy, opts...)
 }
 
+func (api *PrvApi) CreateOrders(reqs []OrderRequest, opts ...OptionParameter) ([]OrderResult, []byte, error) {
+	opts = append(opts,
+		OptionParameter{
+			Key:   "tdMode",
+			Value: "cash",
+		})
+
+	return api.Prv.CreateOrders(reqs, opts...)
+}
+
 func (api *PrvApi) GetHistoryOrders(pair CurrencyPair, opt ...OptionParameter) ([]Order, []byte, error) {
 	opt = append(opt, OptionParameter{
 		Key:   "instType",
@@ -32,3 +42,13 @@ func (api *PrvApi) GetHistoryOrders(pair CurrencyPair, opt ...OptionParameter) (
 	})
 	return api.Prv.GetHistoryOrders(pair, opt...)
 }
+
+func (api *PrvApi) CreateAlgoOrder(req AlgoOrderRequest, opts ...OptionParameter) (*Order, []byte, error) {
+	opts = append(opts,
+		OptionParameter{
+			Key:   "tdMode",
+			Value: "cash",
+		})
+
+	return api.Prv.CreateAlgoOrder(req, opts...)
+}
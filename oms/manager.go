@@ -0,0 +1,611 @@
+package oms
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shadowors/goex/v2"
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/model"
+)
+
+type EventType string
+
+const (
+	EventType_New      EventType = "new"      //订单已登记
+	EventType_Fill     EventType = "fill"     //新增成交
+	EventType_Canceled EventType = "canceled" //已撤销
+	EventType_Rejected EventType = "rejected" //下单被拒绝
+	EventType_Finished EventType = "finished" //全部成交
+)
+
+// Event 订单事件
+type Event struct {
+	Type      EventType
+	Order     model.Order //事件发生后的订单快照
+	FillQty   float64     //本次新增成交数量, 仅EventType_Fill
+	FillPrice float64     //本次新增成交均价, 仅EventType_Fill
+}
+
+type EventHandler func(evt Event)
+
+var ErrOrderNotFound = errors.New("oms: order not found")
+
+const (
+	defaultRetention     = 10 * time.Minute
+	defaultFlushInterval = time.Second
+)
+
+// Manager 订单管理: 登记通过IPrvRest创建的订单, 按订单ID/客户端ID跟踪,
+// 通过REST轮询(Start)或私有推送(Update)驱动状态机并发出成交/撤单/拒单事件,
+// 设置Store后状态变化会持久化未完成订单(Start运行时由后台合并写入), 重启后调用Recover恢复。
+// 终态订单保留retention时间供查询, 之后由Prune清理。
+type Manager struct {
+	goex.IPrvRest
+
+	store         Store
+	handlers      []EventHandler
+	retention     time.Duration
+	flushInterval time.Duration
+
+	mu     sync.RWMutex
+	orders map[string]*model.Order //order id -> order
+	cids   map[string]string       //client id -> order id
+	done   map[string]time.Time    //order id -> 进入终态的时间
+	dirty  bool                    //有未保存的变化
+
+	flushMu sync.Mutex
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+type Option func(m *Manager)
+
+func WithStore(store Store) Option {
+	return func(m *Manager) {
+		m.store = store
+	}
+}
+
+func WithEventHandler(handler EventHandler) Option {
+	return func(m *Manager) {
+		m.handlers = append(m.handlers, handler)
+	}
+}
+
+// WithRetention 终态订单在内存中的保留时间, 默认10分钟
+func WithRetention(retention time.Duration) Option {
+	return func(m *Manager) {
+		m.retention = retention
+	}
+}
+
+// WithFlushInterval Start运行时后台保存订单的间隔, 默认1s
+func WithFlushInterval(interval time.Duration) Option {
+	return func(m *Manager) {
+		m.flushInterval = interval
+	}
+}
+
+func New(api goex.IPrvRest, opts ...Option) *Manager {
+	m := &Manager{
+		IPrvRest:      api,
+		retention:     defaultRetention,
+		flushInterval: defaultFlushInterval,
+		orders:        make(map[string]*model.Order, 64),
+		cids:          make(map[string]string, 64),
+		done:          make(map[string]time.Time, 64),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// CreateOrder 下单并登记到OMS, 下单失败时发出EventType_Rejected
+func (m *Manager) CreateOrder(pair model.CurrencyPair, qty, price float64, side model.OrderSide, orderTy model.OrderType, opt ...model.OptionParameter) (*model.Order, []byte, error) {
+	ord, responseBody, err := m.IPrvRest.CreateOrder(pair, qty, price, side, orderTy, opt...)
+	m.onCreated(model.OrderRequest{Pair: pair, Qty: qty, Price: price, Side: side, OrderTy: orderTy, Opts: opt}, ord, err)
+	return ord, responseBody, err
+}
+
+// CreateOrders 批量下单, 每个订单的结果分别登记或发出EventType_Rejected
+func (m *Manager) CreateOrders(reqs []model.OrderRequest, opt ...model.OptionParameter) ([]model.OrderResult, []byte, error) {
+	results, responseBody, err := m.IPrvRest.CreateOrders(reqs, opt...)
+	for i, req := range reqs {
+		req.Opts = append(req.Opts, opt...)
+		if i >= len(results) {
+			m.onCreated(req, nil, err)
+			continue
+		}
+		ord := results[i].Order
+		if ord == nil && results[i].Err == nil && results[i].Id != "" {
+			ord = &model.Order{Id: results[i].Id, CId: results[i].CId}
+		}
+		m.onCreated(req, ord, results[i].Err)
+	}
+	return results, responseBody, err
+}
+
+// CancelOrder 撤单成功后订单进入Canceling, 最终状态以轮询或推送为准
+func (m *Manager) CancelOrder(pair model.CurrencyPair, id string, opt ...model.OptionParameter) ([]byte, error) {
+	responseBody, err := m.IPrvRest.CancelOrder(pair, id, opt...)
+	if err != nil {
+		return responseBody, err
+	}
+	m.onCanceling(id)
+	return responseBody, err
+}
+
+func (m *Manager) CancelOrders(pair model.CurrencyPair, ids []string, opt ...model.OptionParameter) ([]model.OrderResult, []byte, error) {
+	results, responseBody, err := m.IPrvRest.CancelOrders(pair, ids, opt...)
+	for _, ret := range results {
+		if ret.Err == nil && ret.Id != "" {
+			m.onCanceling(ret.Id)
+		}
+	}
+	return results, responseBody, err
+}
+
+// CancelAllOrders 撤单成功后该交易对的未完成订单进入Canceling
+func (m *Manager) CancelAllOrders(pair model.CurrencyPair, opt ...model.OptionParameter) ([]byte, error) {
+	responseBody, err := m.IPrvRest.CancelAllOrders(pair, opt...)
+	if err != nil {
+		return responseBody, err
+	}
+	for _, ord := range m.OpenOrders() {
+		if ord.Pair.Symbol == pair.Symbol {
+			m.onCanceling(ord.Id)
+		}
+	}
+	return responseBody, err
+}
+
+// AmendOrder 原生改单更新订单数量和价格; 撤单重下时原订单进入Canceling, 新订单登记到OMS
+func (m *Manager) AmendOrder(pair model.CurrencyPair, id string, newQty, newPrice float64, opt ...model.OptionParameter) (*model.AmendResult, []byte, error) {
+	result, responseBody, err := m.IPrvRest.AmendOrder(pair, id, newQty, newPrice, opt...)
+	if result == nil {
+		return result, responseBody, err
+	}
+
+	switch result.Mode {
+	case model.AmendMode_Native:
+		if err == nil {
+			m.mu.Lock()
+			if ord, ok := m.orders[id]; ok {
+				if newQty > 0 {
+					ord.Qty = newQty
+				}
+				if newPrice > 0 {
+					ord.Price = newPrice
+				}
+			}
+			m.mu.Unlock()
+			m.persist()
+		}
+	case model.AmendMode_CancelReplace:
+		orig, _ := m.Get(id)
+		m.onCanceling(id)
+		if result.Order != nil {
+			m.onCreated(model.OrderRequest{Pair: pair, Qty: result.Order.Qty, Price: newPrice,
+				Side: orig.Side, OrderTy: orig.OrderTy, Opts: opt}, result.Order, nil)
+		}
+	}
+
+	return result, responseBody, err
+}
+
+// GetOrderInfo 查询结果同步到OMS
+func (m *Manager) GetOrderInfo(pair model.CurrencyPair, id string, opt ...model.OptionParameter) (*model.Order, []byte, error) {
+	ord, responseBody, err := m.IPrvRest.GetOrderInfo(pair, id, opt...)
+	if err == nil && ord != nil && m.has(ord.Id) {
+		if er := m.Update(*ord); er != nil {
+			logger.Warnf("[oms] %s", er.Error())
+		}
+	}
+	return ord, responseBody, err
+}
+
+// Register 登记订单, 订单必须包含Id和Pair
+func (m *Manager) Register(ord model.Order) error {
+	if ord.Id == "" || ord.Pair.Symbol == "" {
+		return fmt.Errorf("oms: register order requires id and pair")
+	}
+
+	if ord.Status == 0 {
+		ord.Status = model.OrderStatus_Pending
+	}
+	normalizeStatus(&ord)
+
+	m.mu.Lock()
+	if _, ok := m.orders[ord.Id]; ok {
+		m.mu.Unlock()
+		return m.Update(ord)
+	}
+	m.orders[ord.Id] = &ord
+	if ord.CId != "" {
+		m.cids[ord.CId] = ord.Id
+	}
+	if IsFinalStatus(ord.Status) {
+		m.done[ord.Id] = time.Now()
+	}
+	m.mu.Unlock()
+
+	m.emit(Event{Type: EventType_New, Order: ord})
+	if ord.ExecutedQty > 0 {
+		m.emit(Event{Type: EventType_Fill, Order: ord, FillQty: ord.ExecutedQty, FillPrice: ord.PriceAvg})
+	}
+	m.emitFinal(ord)
+	m.persist()
+
+	return nil
+}
+
+// Update 用最新的订单数据驱动状态机, 可用于接入私有websocket推送
+func (m *Manager) Update(latest model.Order) error {
+	normalizeStatus(&latest)
+
+	m.mu.Lock()
+	id := latest.Id
+	if id == "" && latest.CId != "" {
+		id = m.cids[latest.CId]
+	}
+	cur, ok := m.orders[id]
+	if !ok {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: id=%s cid=%s", ErrOrderNotFound, latest.Id, latest.CId)
+	}
+
+	if latest.Status <= 0 ||
+		(cur.Status == model.OrderStatus_Canceling && latest.Status == model.OrderStatus_Pending) { //撤单请求尚未处理完
+		latest.Status = cur.Status
+	}
+
+	if err := checkTransition(cur.Status, latest.Status); err != nil {
+		m.mu.Unlock()
+		return fmt.Errorf("%w, order id=%s", err, cur.Id)
+	}
+
+	prev := *cur
+	merge(cur, &latest)
+	updated := *cur
+	if IsFinalStatus(updated.Status) && !IsFinalStatus(prev.Status) {
+		m.done[updated.Id] = time.Now()
+	}
+	m.mu.Unlock()
+
+	if delta := updated.ExecutedQty - prev.ExecutedQty; delta > 0 {
+		fillPx := updated.PriceAvg
+		if prev.ExecutedQty > 0 && updated.PriceAvg > 0 {
+			fillPx = (updated.PriceAvg*updated.ExecutedQty - prev.PriceAvg*prev.ExecutedQty) / delta
+		}
+		m.emit(Event{Type: EventType_Fill, Order: updated, FillQty: delta, FillPrice: fillPx})
+	}
+
+	if prev.Status != updated.Status {
+		m.emitFinal(updated)
+		m.persist()
+	} else if updated.ExecutedQty != prev.ExecutedQty {
+		m.persist()
+	}
+
+	return nil
+}
+
+// Get 按订单ID查询
+func (m *Manager) Get(id string) (model.Order, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ord, ok := m.orders[id]
+	if !ok {
+		return model.Order{}, false
+	}
+	return *ord, true
+}
+
+// GetByCId 按客户端ID查询
+func (m *Manager) GetByCId(cid string) (model.Order, bool) {
+	m.mu.RLock()
+	id, ok := m.cids[cid]
+	m.mu.RUnlock()
+	if !ok {
+		return model.Order{}, false
+	}
+	return m.Get(id)
+}
+
+// OpenOrders 所有未完成订单
+func (m *Manager) OpenOrders() []model.Order {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	orders := make([]model.Order, 0, len(m.orders))
+	for _, ord := range m.orders {
+		if !IsFinalStatus(ord.Status) {
+			orders = append(orders, *ord)
+		}
+	}
+	return orders
+}
+
+// Poll 通过GetOrderInfo刷新所有未完成订单
+func (m *Manager) Poll() error {
+	var errs []error
+	for _, ord := range m.OpenOrders() {
+		latest, _, err := m.IPrvRest.GetOrderInfo(ord.Pair, ord.Id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("poll order %s: %w", ord.Id, err))
+			continue
+		}
+		if latest == nil {
+			continue
+		}
+		if latest.Id == "" {
+			latest.Id = ord.Id
+		}
+		if err = m.Update(*latest); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Prune 清理进入终态超过retention的订单, 返回清理数量
+func (m *Manager) Prune() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := 0
+	now := time.Now()
+	for id, doneAt := range m.done {
+		if now.Sub(doneAt) < m.retention {
+			continue
+		}
+		if ord, ok := m.orders[id]; ok && ord.CId != "" && m.cids[ord.CId] == id {
+			delete(m.cids, ord.CId)
+		}
+		delete(m.orders, id)
+		delete(m.done, id)
+		n++
+	}
+
+	return n
+}
+
+// Flush 立即保存未完成订单, 没有变化时不写入
+func (m *Manager) Flush() error {
+	if m.store == nil {
+		return nil
+	}
+
+	m.flushMu.Lock()
+	defer m.flushMu.Unlock()
+
+	m.mu.Lock()
+	dirty := m.dirty
+	m.dirty = false
+	m.mu.Unlock()
+	if !dirty {
+		return nil
+	}
+
+	if err := m.store.Save(m.OpenOrders()); err != nil {
+		m.mu.Lock()
+		m.dirty = true
+		m.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Start 后台按interval轮询未完成订单并清理过期的终态订单, 按flushInterval保存订单
+func (m *Manager) Start(interval time.Duration) {
+	m.mu.Lock()
+	if m.stopCh != nil {
+		m.mu.Unlock()
+		return
+	}
+	m.stopCh = make(chan struct{})
+	stopCh := m.stopCh
+	m.mu.Unlock()
+
+	m.wg.Add(2)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				if err := m.Poll(); err != nil {
+					logger.Warnf("[oms] poll error: %s", err.Error())
+				}
+				m.Prune()
+			}
+		}
+	}()
+
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(m.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				if err := m.Flush(); err != nil {
+					logger.Errorf("[oms] save orders error: %s", err.Error())
+				}
+			}
+		}
+	}()
+}
+
+// Stop 停止后台任务并保存尚未写入的变化
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	stopCh := m.stopCh
+	m.stopCh = nil
+	m.mu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		m.wg.Wait()
+	}
+
+	if err := m.Flush(); err != nil {
+		logger.Errorf("[oms] save orders error: %s", err.Error())
+	}
+}
+
+// Recover 从Store恢复未完成订单并立即轮询一次与交易所对账
+func (m *Manager) Recover() error {
+	if m.store == nil {
+		return nil
+	}
+
+	orders, err := m.store.Load()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	for i := range orders {
+		ord := orders[i]
+		m.orders[ord.Id] = &ord
+		if ord.CId != "" {
+			m.cids[ord.CId] = ord.Id
+		}
+	}
+	m.mu.Unlock()
+
+	logger.Infof("[oms] recover %d open orders", len(orders))
+
+	return m.Poll()
+}
+
+func (m *Manager) onCreated(req model.OrderRequest, ord *model.Order, err error) {
+	if err != nil || ord == nil {
+		rejected := model.Order{Pair: req.Pair, Qty: req.Qty, Price: req.Price, Side: req.Side, OrderTy: req.OrderTy,
+			Status: model.OrderStatus_Rejected, CreatedAt: time.Now().UnixMilli()}
+		rejected.CId = req.Opt(model.Order_Client_ID__Opt_Key)
+		m.emit(Event{Type: EventType_Rejected, Order: rejected})
+		return
+	}
+
+	registered := *ord
+	if registered.Pair.Symbol == "" {
+		registered.Pair = req.Pair
+	}
+	if registered.Qty == 0 {
+		registered.Qty = req.Qty
+	}
+	if registered.Price == 0 {
+		registered.Price = req.Price
+	}
+	if registered.Side == "" {
+		registered.Side = req.Side
+	}
+	if registered.OrderTy == "" {
+		registered.OrderTy = req.OrderTy
+	}
+	if registered.CreatedAt == 0 {
+		registered.CreatedAt = time.Now().UnixMilli()
+	}
+
+	if err := m.Register(registered); err != nil {
+		logger.Warnf("[oms] register order %s error: %s", registered.Id, err.Error())
+	}
+}
+
+func (m *Manager) onCanceling(id string) {
+	m.mu.RLock()
+	ord, ok := m.orders[id]
+	var canceling model.Order
+	if ok {
+		canceling = *ord
+	}
+	m.mu.RUnlock()
+
+	if ok && !IsFinalStatus(canceling.Status) {
+		canceling.Status = model.OrderStatus_Canceling
+		if er := m.Update(canceling); er != nil {
+			logger.Warnf("[oms] %s", er.Error())
+		}
+	}
+}
+
+func (m *Manager) has(id string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.orders[id]
+	return ok
+}
+
+func (m *Manager) emitFinal(ord model.Order) {
+	switch ord.Status {
+	case model.OrderStatus_Finished:
+		m.emit(Event{Type: EventType_Finished, Order: ord})
+	case model.OrderStatus_Canceled:
+		m.emit(Event{Type: EventType_Canceled, Order: ord})
+	case model.OrderStatus_Rejected:
+		m.emit(Event{Type: EventType_Rejected, Order: ord})
+	}
+}
+
+func (m *Manager) emit(evt Event) {
+	for _, h := range m.handlers {
+		h(evt)
+	}
+}
+
+// persist 标记有变化, Start运行时由后台按flushInterval合并写入, 否则立即写入; 只保存未完成订单
+func (m *Manager) persist() {
+	if m.store == nil {
+		return
+	}
+
+	m.mu.Lock()
+	m.dirty = true
+	running := m.stopCh != nil
+	m.mu.Unlock()
+
+	if running {
+		return
+	}
+
+	if err := m.Flush(); err != nil {
+		logger.Errorf("[oms] save orders error: %s", err.Error())
+	}
+}
+
+// merge 合并最新订单数据, 零值字段保留原值
+func merge(cur, latest *model.Order) {
+	cur.Status = latest.Status
+	if latest.CId != "" {
+		cur.CId = latest.CId
+	}
+	if latest.ExecutedQty > cur.ExecutedQty {
+		cur.ExecutedQty = latest.ExecutedQty
+	}
+	if latest.PriceAvg > 0 {
+		cur.PriceAvg = latest.PriceAvg
+	}
+	if latest.Fee != 0 {
+		cur.Fee = latest.Fee
+	}
+	if latest.FeeCcy != "" {
+		cur.FeeCcy = latest.FeeCcy
+	}
+	if latest.FinishedAt > 0 {
+		cur.FinishedAt = latest.FinishedAt
+	}
+	if latest.CanceledAt > 0 {
+		cur.CanceledAt = latest.CanceledAt
+	}
+}
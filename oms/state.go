@@ -0,0 +1,56 @@
+package oms
+
+import (
+	"fmt"
+
+	"github.com/shadowors/goex/v2/model"
+)
+
+// transitions 订单状态机, 终态(Finished/Canceled/Rejected)不能再迁移
+var transitions = map[model.OrderStatus][]model.OrderStatus{
+	model.OrderStatus_Pending: {
+		model.OrderStatus_PartFinished,
+		model.OrderStatus_Finished,
+		model.OrderStatus_Canceling,
+		model.OrderStatus_Canceled,
+		model.OrderStatus_Rejected,
+	},
+	model.OrderStatus_PartFinished: {
+		model.OrderStatus_PartFinished,
+		model.OrderStatus_Finished,
+		model.OrderStatus_Canceling,
+		model.OrderStatus_Canceled,
+	},
+	model.OrderStatus_Canceling: {
+		model.OrderStatus_PartFinished, //撤单过程中仍可能成交
+		model.OrderStatus_Finished,
+		model.OrderStatus_Canceled,
+	},
+}
+
+// IsFinalStatus 是否为终态
+func IsFinalStatus(s model.OrderStatus) bool {
+	return s == model.OrderStatus_Finished ||
+		s == model.OrderStatus_Canceled ||
+		s == model.OrderStatus_Rejected
+}
+
+// checkTransition 校验状态迁移, 相同状态视为合法(无变化)
+func checkTransition(from, to model.OrderStatus) error {
+	if from == to {
+		return nil
+	}
+	for _, s := range transitions[from] {
+		if s == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("oms: invalid order status transition %s -> %s", from, to)
+}
+
+// normalizeStatus 部分交易所已部分成交的订单仍返回pending
+func normalizeStatus(ord *model.Order) {
+	if ord.Status == model.OrderStatus_Pending && ord.ExecutedQty > 0 {
+		ord.Status = model.OrderStatus_PartFinished
+	}
+}
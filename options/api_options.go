@@ -1,10 +1,32 @@
 package options
 
+import (
+	"time"
+
+	"github.com/shadowors/goex/v2/signer"
+	"github.com/shadowors/goex/v2/util"
+	"github.com/shadowors/goex/v2/validator"
+)
+
 type ApiOptions struct {
-	Key        string
-	Secret     string
-	Passphrase string
-	ClientId   string
+	Key             string
+	Secret          string
+	Passphrase      string
+	ClientId        string
+	PrivateKey      string                //PEM格式私钥(Ed25519/RSA), 设置后binance使用非对称签名, 否则使用Secret做HMAC签名
+	PrivateKeyFile  string                //PEM私钥文件路径, 与PrivateKey二选一
+	Signer          signer.Signer         //自定义签名(如远程签名服务), 设置后忽略Secret/PrivateKey
+	OrderValidators []validator.Validator //下单前校验, 默认validator.Defaults()
+	Clock           *util.Clock           //签名使用的校正时钟, 为空时使用本地时间
+	RecvWindow      time.Duration         //请求有效时长, binance默认6s; okx仅用于下单/改单/撤单(expTime), 为空时不限制
+}
+
+// Now 签名时间戳
+func (o ApiOptions) Now() time.Time {
+	if o.Clock == nil {
+		return time.Now()
+	}
+	return o.Clock.Now()
 }
 
 type ApiOption func(options *ApiOptions)
@@ -27,8 +49,48 @@ func WithPassphrase(passphrase string) ApiOption {
 	}
 }
 
+// WithPrivateKey PEM格式的Ed25519或RSA私钥, 目前仅binance支持
+func WithPrivateKey(pemKey string) ApiOption {
+	return func(options *ApiOptions) {
+		options.PrivateKey = pemKey
+	}
+}
+
+func WithPrivateKeyFile(path string) ApiOption {
+	return func(options *ApiOptions) {
+		options.PrivateKeyFile = path
+	}
+}
+
+// WithSigner 使用自定义签名, 如signer.NewRemoteSigner, 此时无需设置Secret
+func WithSigner(s signer.Signer) ApiOption {
+	return func(options *ApiOptions) {
+		options.Signer = s
+	}
+}
+
 func WithClientId(clientId string) ApiOption {
 	return func(options *ApiOptions) {
 		options.ClientId = clientId
 	}
 }
+
+// WithOrderValidators 追加下单前校验规则, 校验失败时CreateOrder直接返回*validator.Error
+func WithOrderValidators(validators ...validator.Validator) ApiOption {
+	return func(options *ApiOptions) {
+		options.OrderValidators = append(options.OrderValidators, validators...)
+	}
+}
+
+// WithClock 使用与交易所服务器时间校正后的时钟签名, 见util.NewClock
+func WithClock(clock *util.Clock) ApiOption {
+	return func(options *ApiOptions) {
+		options.Clock = clock
+	}
+}
+
+func WithRecvWindow(recvWindow time.Duration) ApiOption {
+	return func(options *ApiOptions) {
+		options.RecvWindow = recvWindow
+	}
+}
@@ -0,0 +1,10 @@
+package options
+
+// Environment 交易所运行环境, 各交易所通过WithEnvironment一次性设置REST/websocket地址和必要的header
+type Environment string
+
+const (
+	Env_Production Environment = "production"
+	Env_Testnet    Environment = "testnet" //okx模拟盘, binance测试网
+	Env_AWS        Environment = "aws"     //AWS/colo专线域名
+)
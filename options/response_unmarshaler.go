@@ -21,6 +21,24 @@ type GetAssetValuationResponseUnmarshaler func([]byte) (*model.AssetValuation, e
 type GetAssetBalancesResponseUnmarshaler func([]byte) (map[string]model.AssetBalance, error)
 type GetAssetBillsResponseUnmarshaler func([]byte) ([]model.AssetBill, error)
 type GetAssetCurrenciesResponseUnmarshaler func([]byte) ([]model.AssetCurrency, error)
+type GetPriceLimitResponseUnmarshaler func([]byte) (*model.PriceLimit, error)
+type CreateOrdersResponseUnmarshaler func([]byte) ([]model.OrderResult, error)
+type CancelOrdersResponseUnmarshaler func([]byte) ([]model.OrderResult, error)
+type AmendOrderResponseUnmarshaler func([]byte) (*model.Order, error)
+type CancelAllOrdersResponseUnmarshaler func([]byte) error
+type GetLeverageResponseUnmarshaler func([]byte) (float64, error)
+type CreateAlgoOrderResponseUnmarshaler func([]byte) (*model.Order, error)
+type GetAlgoOrderInfoResponseUnmarshaler func([]byte) (*model.Order, error)
+type GetPendingAlgoOrdersResponseUnmarshaler func([]byte) ([]model.Order, error)
+type CancelAlgoOrderResponseUnmarshaler func([]byte) error
+type GetFillsResponseUnmarshaler func([]byte) ([]model.Trade, error)
+type GetTradesResponseUnmarshaler func([]byte) ([]model.Trade, error)
+type GetHistoryTradesResponseUnmarshaler func([]byte) ([]model.Trade, error)
+type GetTickersResponseUnmarshaler func([]byte) (map[string]model.Ticker, error)
+type GetBookTickersResponseUnmarshaler func([]byte) (map[string]model.Ticker, error)
+type GetServerTimeResponseUnmarshaler func([]byte) (int64, error)
+type TransferResponseUnmarshaler func([]byte) (*model.Transfer, error)
+type GetTransfersResponseUnmarshaler func([]byte) ([]model.Transfer, error)
 
 type UnmarshalerOptions struct {
 	ResponseUnmarshaler                      ResponseUnmarshaler
@@ -42,6 +60,24 @@ type UnmarshalerOptions struct {
 	GetAssetBalancesResponseUnmarshaler      GetAssetBalancesResponseUnmarshaler
 	GetAssetBillsResponseUnmarshaler         GetAssetBillsResponseUnmarshaler
 	GetAssetCurrenciesResponseUnmarshaler    GetAssetCurrenciesResponseUnmarshaler
+	GetPriceLimitResponseUnmarshaler         GetPriceLimitResponseUnmarshaler
+	CreateOrdersResponseUnmarshaler          CreateOrdersResponseUnmarshaler
+	CancelOrdersResponseUnmarshaler          CancelOrdersResponseUnmarshaler
+	AmendOrderResponseUnmarshaler            AmendOrderResponseUnmarshaler
+	CancelAllOrdersResponseUnmarshaler       CancelAllOrdersResponseUnmarshaler
+	GetLeverageResponseUnmarshaler           GetLeverageResponseUnmarshaler
+	CreateAlgoOrderResponseUnmarshaler       CreateAlgoOrderResponseUnmarshaler
+	GetAlgoOrderInfoResponseUnmarshaler      GetAlgoOrderInfoResponseUnmarshaler
+	GetPendingAlgoOrdersResponseUnmarshaler  GetPendingAlgoOrdersResponseUnmarshaler
+	CancelAlgoOrderResponseUnmarshaler       CancelAlgoOrderResponseUnmarshaler
+	GetFillsResponseUnmarshaler              GetFillsResponseUnmarshaler
+	GetTradesResponseUnmarshaler             GetTradesResponseUnmarshaler
+	GetHistoryTradesResponseUnmarshaler      GetHistoryTradesResponseUnmarshaler
+	GetTickersResponseUnmarshaler            GetTickersResponseUnmarshaler
+	GetBookTickersResponseUnmarshaler        GetBookTickersResponseUnmarshaler
+	GetServerTimeResponseUnmarshaler         GetServerTimeResponseUnmarshaler
+	TransferResponseUnmarshaler              TransferResponseUnmarshaler
+	GetTransfersResponseUnmarshaler          GetTransfersResponseUnmarshaler
 }
 
 type UnmarshalerOption func(options *UnmarshalerOptions)
@@ -158,3 +194,111 @@ func WithGetAssetCurrenciesResponseUnmarshaler(unmarshaler GetAssetCurrenciesRes
 		options.GetAssetCurrenciesResponseUnmarshaler = unmarshaler
 	}
 }
+
+func WithGetPriceLimitResponseUnmarshaler(unmarshaler GetPriceLimitResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetPriceLimitResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithCreateOrdersResponseUnmarshaler(unmarshaler CreateOrdersResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.CreateOrdersResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithCancelOrdersResponseUnmarshaler(unmarshaler CancelOrdersResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.CancelOrdersResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithAmendOrderResponseUnmarshaler(unmarshaler AmendOrderResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.AmendOrderResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithCancelAllOrdersResponseUnmarshaler(unmarshaler CancelAllOrdersResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.CancelAllOrdersResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithGetLeverageResponseUnmarshaler(unmarshaler GetLeverageResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetLeverageResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithCreateAlgoOrderResponseUnmarshaler(unmarshaler CreateAlgoOrderResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.CreateAlgoOrderResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithGetAlgoOrderInfoResponseUnmarshaler(unmarshaler GetAlgoOrderInfoResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetAlgoOrderInfoResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithGetPendingAlgoOrdersResponseUnmarshaler(unmarshaler GetPendingAlgoOrdersResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetPendingAlgoOrdersResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithCancelAlgoOrderResponseUnmarshaler(unmarshaler CancelAlgoOrderResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.CancelAlgoOrderResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithGetFillsResponseUnmarshaler(unmarshaler GetFillsResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetFillsResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithGetTradesResponseUnmarshaler(unmarshaler GetTradesResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetTradesResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithGetHistoryTradesResponseUnmarshaler(unmarshaler GetHistoryTradesResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetHistoryTradesResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithGetTickersResponseUnmarshaler(unmarshaler GetTickersResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetTickersResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithGetBookTickersResponseUnmarshaler(unmarshaler GetBookTickersResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetBookTickersResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithGetServerTimeResponseUnmarshaler(unmarshaler GetServerTimeResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetServerTimeResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithTransferResponseUnmarshaler(unmarshaler TransferResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.TransferResponseUnmarshaler = unmarshaler
+	}
+}
+
+func WithGetTransfersResponseUnmarshaler(unmarshaler GetTransfersResponseUnmarshaler) UnmarshalerOption {
+	return func(options *UnmarshalerOptions) {
+		options.GetTransfersResponseUnmarshaler = unmarshaler
+	}
+}
@@ -2,6 +2,10 @@ package options
 
 type UriOptions struct {
 	Endpoint                 string
+	WsEndpoint               string            //公共行情websocket
+	WsPrivateEndpoint        string            //私有频道/交易websocket
+	SpotEndpoint             string            //部分接口只在现货域名下(如火币合约账户的划转), 与对应的Uri拼接
+	Headers                  map[string]string //每个请求附加的header, 如okx模拟盘的x-simulated-trading
 	TickerUri                string
 	DepthUri                 string
 	KlineUri                 string
@@ -19,6 +23,34 @@ type UriOptions struct {
 	GetAssetBalancesUri      string
 	GetAssetBillsUri         string
 	GetAssetCurrenciesUri    string
+	GetPriceLimitUri         string
+	NewOrdersUri             string
+	CancelOrdersUri          string
+	AmendOrderUri            string
+	CancelAllOrdersUri       string
+	CancelAllAfterUri        string
+	SetLeverageUri           string
+	GetLeverageUri           string
+	SetMarginModeUri         string
+	SetPositionModeUri       string
+	AdjustMarginUri          string
+	NewAlgoOrderUri          string
+	GetAlgoOrderUri          string
+	GetPendingAlgoOrdersUri  string
+	CancelAlgoOrderUri       string
+	NewTpslOrderUri          string
+	GetPendingTpslOrdersUri  string
+	GetHistoryTpslOrdersUri  string
+	CancelTpslOrderUri       string
+	GetFillsUri              string
+	GetFillsHistoryUri       string
+	GetTradesUri             string
+	GetHistoryTradesUri      string
+	GetTickersUri            string
+	GetBookTickersUri        string
+	GetServerTimeUri         string
+	TransferUri              string
+	GetTransferUri           string
 }
 
 type UriOption func(*UriOptions)
@@ -130,3 +162,198 @@ func WithGetAssetCurrenciesUri(uri string) UriOption {
 		c.GetAssetCurrenciesUri = uri
 	}
 }
+
+func WithGetPriceLimitUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetPriceLimitUri = uri
+	}
+}
+
+func WithNewOrdersUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.NewOrdersUri = uri
+	}
+}
+
+func WithCancelOrdersUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.CancelOrdersUri = uri
+	}
+}
+
+func WithAmendOrderUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.AmendOrderUri = uri
+	}
+}
+
+func WithCancelAllOrdersUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.CancelAllOrdersUri = uri
+	}
+}
+
+func WithCancelAllAfterUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.CancelAllAfterUri = uri
+	}
+}
+
+func WithSetLeverageUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.SetLeverageUri = uri
+	}
+}
+
+func WithGetLeverageUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetLeverageUri = uri
+	}
+}
+
+func WithSetMarginModeUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.SetMarginModeUri = uri
+	}
+}
+
+func WithSetPositionModeUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.SetPositionModeUri = uri
+	}
+}
+
+func WithAdjustMarginUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.AdjustMarginUri = uri
+	}
+}
+
+func WithNewAlgoOrderUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.NewAlgoOrderUri = uri
+	}
+}
+
+func WithGetAlgoOrderUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetAlgoOrderUri = uri
+	}
+}
+
+func WithGetPendingAlgoOrdersUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetPendingAlgoOrdersUri = uri
+	}
+}
+
+func WithCancelAlgoOrderUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.CancelAlgoOrderUri = uri
+	}
+}
+
+func WithNewTpslOrderUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.NewTpslOrderUri = uri
+	}
+}
+
+func WithGetPendingTpslOrdersUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetPendingTpslOrdersUri = uri
+	}
+}
+
+func WithGetHistoryTpslOrdersUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetHistoryTpslOrdersUri = uri
+	}
+}
+
+func WithCancelTpslOrderUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.CancelTpslOrderUri = uri
+	}
+}
+
+func WithGetFillsUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetFillsUri = uri
+	}
+}
+
+func WithGetFillsHistoryUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetFillsHistoryUri = uri
+	}
+}
+
+func WithGetTradesUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetTradesUri = uri
+	}
+}
+
+func WithGetHistoryTradesUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetHistoryTradesUri = uri
+	}
+}
+
+func WithGetTickersUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetTickersUri = uri
+	}
+}
+
+func WithGetBookTickersUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetBookTickersUri = uri
+	}
+}
+
+func WithGetServerTimeUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetServerTimeUri = uri
+	}
+}
+
+func WithSpotEndpoint(endpoint string) UriOption {
+	return func(c *UriOptions) {
+		c.SpotEndpoint = endpoint
+	}
+}
+
+func WithWsEndpoint(endpoint string) UriOption {
+	return func(c *UriOptions) {
+		c.WsEndpoint = endpoint
+	}
+}
+
+func WithWsPrivateEndpoint(endpoint string) UriOption {
+	return func(c *UriOptions) {
+		c.WsPrivateEndpoint = endpoint
+	}
+}
+
+func WithTransferUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.TransferUri = uri
+	}
+}
+
+func WithGetTransferUri(uri string) UriOption {
+	return func(c *UriOptions) {
+		c.GetTransferUri = uri
+	}
+}
+
+func WithHeader(key, value string) UriOption {
+	return func(c *UriOptions) {
+		if c.Headers == nil {
+			c.Headers = make(map[string]string, 1)
+		}
+		c.Headers[key] = value
+	}
+}
@@ -0,0 +1,455 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/shadowors/goex/v2"
+	"github.com/shadowors/goex/v2/instrument"
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/util"
+)
+
+// exchangeInfoGetter 私有接口同时提供GetExchangeInfo时, 用于补全持仓的交易对信息
+type exchangeInfoGetter interface {
+	GetExchangeInfo() (map[string]model.CurrencyPair, []byte, error)
+}
+
+// AssetBalancesGetter 资金账户余额, 如: OKxV5的GetAssetBalances
+type AssetBalancesGetter interface {
+	GetAssetBalances(currency string) (map[string]model.AssetBalance, []byte, error)
+}
+
+// Account 一个交易所账户.
+// OKX统一账户下现货和合约共用交易账户, Spot和Futures只需设置一个, 否则余额会重复计算.
+type Account struct {
+	Name    string
+	Venue   string //instrument.Registry中的名称, 如model.OKX_SWAP, 用于补全持仓的合约面值和标的币
+	Spot    goex.IPrvRest
+	Futures goex.IFuturesPrvRest
+	Funding AssetBalancesGetter  //资金账户, 可为nil
+	Pairs   []model.CurrencyPair //需要采集持仓的合约, 为空时获取所有持仓
+
+	// RealizedPnL 已实现盈亏来源, 如: risk.Guard.RealizedPnL, 单位需与Portfolio计价币一致.
+	// 为nil时累加持仓返回的已实现盈亏
+	RealizedPnL func() float64
+}
+
+// AssetExposure 单个币种的敞口, 包含现货余额/资金账户余额/合约保证金余额和合约持仓
+type AssetExposure struct {
+	Asset string  `json:"asset"`
+	Qty   float64 `json:"qty"`   //净数量, 单位: 币
+	Gross float64 `json:"gross"` //合约持仓绝对值之和加上余额, 单位: 币
+	Price float64 `json:"price"` //对计价币价格
+	Value float64 `json:"value"` //净价值(计价币)
+}
+
+type AccountSnapshot struct {
+	Name              string                          `json:"name"`
+	Equity            float64                         `json:"equity"`
+	UnrealizedPnL     float64                         `json:"unrealized_pnl"`
+	RealizedPnL       float64                         `json:"realized_pnl"`
+	UsedMargin        float64                         `json:"used_margin"`
+	MarginUtilization float64                         `json:"margin_utilization"` //占用保证金/合约账户权益
+	Balances          map[string]model.Account        `json:"balances,omitempty"`
+	FuturesAccounts   map[string]model.FuturesAccount `json:"futures_accounts,omitempty"`
+	AssetBalances     map[string]model.AssetBalance   `json:"asset_balances,omitempty"`
+	Positions         []model.FuturesPosition         `json:"positions,omitempty"`
+	Errors            []string                        `json:"errors,omitempty"`
+
+	futuresEquity float64
+}
+
+// Snapshot 所有账户按计价币汇总的结果
+type Snapshot struct {
+	Ts                int64                    `json:"ts"`
+	Quote             string                   `json:"quote"`
+	TotalEquity       float64                  `json:"total_equity"`
+	UnrealizedPnL     float64                  `json:"unrealized_pnl"`
+	RealizedPnL       float64                  `json:"realized_pnl"`
+	UsedMargin        float64                  `json:"used_margin"`
+	MarginUtilization float64                  `json:"margin_utilization"`
+	Exposures         map[string]AssetExposure `json:"exposures"`
+	Accounts          []AccountSnapshot        `json:"accounts"`
+}
+
+type SnapshotHandler func(snapshot *Snapshot)
+
+// Portfolio 定时采集多个账户的余额和持仓, 按计价币生成快照
+type Portfolio struct {
+	quote    string
+	prices   PriceSource
+	registry *instrument.Registry
+	accounts []Account
+	handlers []SnapshotHandler
+
+	mu     sync.RWMutex
+	latest *Snapshot
+
+	pairsMu sync.Mutex
+	pairs   map[string]map[string]model.CurrencyPair //account name -> symbol, 来自GetExchangeInfo
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+type Option func(p *Portfolio)
+
+func WithAccount(acc Account) Option {
+	return func(p *Portfolio) {
+		p.accounts = append(p.accounts, acc)
+	}
+}
+
+// WithRegistry 持仓交易对信息来源, 默认instrument.DefaultRegistry
+func WithRegistry(registry *instrument.Registry) Option {
+	return func(p *Portfolio) {
+		p.registry = registry
+	}
+}
+
+func WithSnapshotHandler(handler SnapshotHandler) Option {
+	return func(p *Portfolio) {
+		p.handlers = append(p.handlers, handler)
+	}
+}
+
+// New
+//
+//	quote  计价币, 如: USDT
+//	prices 价格来源, 一般为NewTickerPriceSource
+func New(quote string, prices PriceSource, opts ...Option) *Portfolio {
+	p := &Portfolio{
+		quote:    quote,
+		prices:   prices,
+		registry: instrument.DefaultRegistry,
+		pairs:    make(map[string]map[string]model.CurrencyPair, 4),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Collect 采集一次所有账户. 单个接口失败不影响其他数据, 错误记录在AccountSnapshot.Errors
+func (p *Portfolio) Collect() *Snapshot {
+	snap := &Snapshot{
+		Ts:        time.Now().UnixMilli(),
+		Quote:     p.quote,
+		Exposures: make(map[string]AssetExposure, 16),
+		Accounts:  make([]AccountSnapshot, 0, len(p.accounts)),
+	}
+
+	var futuresEquity float64
+	for _, acc := range p.accounts {
+		accSnap := p.collectAccount(acc, snap.Exposures)
+		snap.TotalEquity += accSnap.Equity
+		snap.UnrealizedPnL += accSnap.UnrealizedPnL
+		snap.RealizedPnL += accSnap.RealizedPnL
+		snap.UsedMargin += accSnap.UsedMargin
+		futuresEquity += accSnap.futuresEquity
+		snap.Accounts = append(snap.Accounts, accSnap)
+	}
+
+	if futuresEquity > 0 {
+		snap.MarginUtilization = snap.UsedMargin / futuresEquity
+	}
+
+	for asset, expo := range snap.Exposures {
+		if px, err := p.prices.Price(asset, p.quote); err == nil {
+			expo.Price = px
+			expo.Value = expo.Qty * px
+		}
+		snap.Exposures[asset] = expo
+	}
+
+	p.mu.Lock()
+	p.latest = snap
+	p.mu.Unlock()
+
+	for _, h := range p.handlers {
+		h(snap)
+	}
+
+	return snap
+}
+
+// Latest 最近一次采集的快照, 未采集时返回nil
+func (p *Portfolio) Latest() *Snapshot {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.latest
+}
+
+// Start 后台按interval采集
+func (p *Portfolio) Start(interval time.Duration) {
+	p.mu.Lock()
+	if p.stopCh != nil {
+		p.mu.Unlock()
+		return
+	}
+	p.stopCh = make(chan struct{})
+	stopCh := p.stopCh
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.Collect()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				p.Collect()
+			}
+		}
+	}()
+}
+
+func (p *Portfolio) Stop() {
+	p.mu.Lock()
+	stopCh := p.stopCh
+	p.stopCh = nil
+	p.mu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		p.wg.Wait()
+	}
+}
+
+func (p *Portfolio) collectAccount(acc Account, exposures map[string]AssetExposure) (snap AccountSnapshot) {
+	snap.Name = acc.Name
+
+	addErr := func(api string, err error) {
+		logger.Warnf("[portfolio] %s %s error: %s", acc.Name, api, err.Error())
+		snap.Errors = append(snap.Errors, fmt.Sprintf("%s: %s", api, err.Error()))
+	}
+
+	addBalance := func(asset string, qty float64) {
+		if qty == 0 {
+			return
+		}
+		expo := exposures[asset]
+		expo.Asset = asset
+		expo.Qty += qty
+		expo.Gross += math.Abs(qty)
+		exposures[asset] = expo
+		snap.Equity += p.value(asset, qty, addErr)
+	}
+
+	if acc.Spot != nil {
+		err := safeCall(func() (err error) {
+			snap.Balances, _, err = acc.Spot.GetAccount("")
+			return
+		})
+		if err != nil {
+			addErr("GetAccount", err)
+		}
+		for coin, bal := range snap.Balances {
+			addBalance(coin, bal.Balance)
+		}
+	}
+
+	if acc.Funding != nil {
+		err := safeCall(func() (err error) {
+			snap.AssetBalances, _, err = acc.Funding.GetAssetBalances("")
+			return
+		})
+		if err != nil {
+			addErr("GetAssetBalances", err)
+		}
+		for coin, bal := range snap.AssetBalances {
+			addBalance(coin, bal.Bal)
+		}
+	}
+
+	if acc.Futures != nil {
+		err := safeCall(func() (err error) {
+			snap.FuturesAccounts, _, err = acc.Futures.GetFuturesAccount("")
+			return
+		})
+		if err != nil {
+			addErr("GetFuturesAccount", err)
+		}
+		for coin, fa := range snap.FuturesAccounts {
+			eq := p.value(coin, fa.Eq, addErr)
+			addBalance(coin, fa.Eq)
+			snap.futuresEquity += eq
+			if fa.AvailEq > 0 && fa.Eq > fa.AvailEq {
+				snap.UsedMargin += p.value(coin, fa.Eq-fa.AvailEq, addErr)
+			}
+		}
+
+		snap.Positions = p.collectPositions(acc, addErr)
+		for i := range snap.Positions {
+			pos := &snap.Positions[i]
+			pair, err := p.resolvePair(acc, pos.Pair)
+			if err != nil {
+				addErr("ResolvePair", err)
+			}
+			pos.Pair = pair
+
+			settle := settleCurrency(pos.Pair, p.quote)
+			snap.UnrealizedPnL += p.value(settle, pos.Upl, addErr)
+			if acc.RealizedPnL == nil {
+				snap.RealizedPnL += p.value(settle, pos.Rpl, addErr)
+			}
+
+			if err != nil { //缺少标的币和面值时无法计算敞口
+				continue
+			}
+
+			asset := pos.Pair.BaseSymbol
+			contracts := util.PositionQty(*pos)
+			qty := math.Copysign(util.ContractsToQty(pos.Pair, math.Abs(contracts), pos.AvgPx), contracts)
+			if qty == 0 {
+				continue
+			}
+			expo := exposures[asset]
+			expo.Asset = asset
+			expo.Qty += qty
+			expo.Gross += math.Abs(qty)
+			exposures[asset] = expo
+		}
+	}
+
+	if acc.RealizedPnL != nil {
+		snap.RealizedPnL = acc.RealizedPnL()
+	}
+
+	if snap.futuresEquity > 0 {
+		snap.MarginUtilization = snap.UsedMargin / snap.futuresEquity
+	}
+
+	return snap
+}
+
+func (p *Portfolio) collectPositions(acc Account, addErr func(string, error)) []model.FuturesPosition {
+	pairs := acc.Pairs
+	if len(pairs) == 0 {
+		pairs = []model.CurrencyPair{{}}
+	}
+
+	var positions []model.FuturesPosition
+	for _, pair := range pairs {
+		err := safeCall(func() error {
+			pos, _, err := acc.Futures.GetPositions(pair)
+			if err != nil {
+				return err
+			}
+			positions = append(positions, pos...)
+			return nil
+		})
+		if err != nil {
+			addErr("GetPositions "+pair.Symbol, err)
+		}
+	}
+	return positions
+}
+
+// resolvePair 持仓返回的交易对可能只有Symbol(如binance、huobi获取全部持仓时),
+// 依次从Account.Pairs、instrument.Registry、GetExchangeInfo补全面值和标的币
+func (p *Portfolio) resolvePair(acc Account, pair model.CurrencyPair) (model.CurrencyPair, error) {
+	if pair.ContractVal > 0 && pair.BaseSymbol != "" {
+		return pair, nil
+	}
+
+	for _, cp := range acc.Pairs {
+		if cp.Symbol == pair.Symbol && cp.ContractVal > 0 {
+			return cp, nil
+		}
+	}
+
+	if acc.Venue != "" && p.registry != nil {
+		if inst, ok := p.registry.Get(acc.Venue, pair.Symbol); ok {
+			return inst.Pair, nil
+		}
+	}
+
+	pairs, err := p.exchangePairs(acc)
+	if err != nil {
+		return pair, fmt.Errorf("resolve %s: %w", pair.Symbol, err)
+	}
+	if cp, ok := pairs[pair.Symbol]; ok {
+		return cp, nil
+	}
+
+	return pair, fmt.Errorf("currency pair %s not found", pair.Symbol)
+}
+
+// exchangePairs 每个账户只成功加载一次
+func (p *Portfolio) exchangePairs(acc Account) (map[string]model.CurrencyPair, error) {
+	p.pairsMu.Lock()
+	defer p.pairsMu.Unlock()
+
+	if pairs, ok := p.pairs[acc.Name]; ok {
+		return pairs, nil
+	}
+
+	getter, ok := acc.Futures.(exchangeInfoGetter)
+	if !ok {
+		return nil, errors.New("futures api not support GetExchangeInfo, set Account.Venue and load instrument registry")
+	}
+
+	var info map[string]model.CurrencyPair
+	err := safeCall(func() (err error) {
+		info, _, err = getter.GetExchangeInfo()
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make(map[string]model.CurrencyPair, len(info))
+	for _, cp := range info {
+		pairs[cp.Symbol] = cp
+	}
+	p.pairs[acc.Name] = pairs
+
+	return pairs, nil
+}
+
+// value 换算为计价币, 获取不到价格时记录错误并返回0
+func (p *Portfolio) value(asset string, qty float64, addErr func(string, error)) float64 {
+	if qty == 0 {
+		return 0
+	}
+	px, err := p.prices.Price(asset, p.quote)
+	if err != nil {
+		addErr("Price", err)
+		return 0
+	}
+	return qty * px
+}
+
+// settleCurrency 持仓盈亏的结算币, 币本位合约为标的币, 否则为计价币
+func settleCurrency(pair model.CurrencyPair, def string) string {
+	if pair.SettlementCurrency != "" {
+		return pair.SettlementCurrency
+	}
+	if pair.ContractValCurrency != "" && pair.ContractValCurrency != pair.BaseSymbol {
+		return pair.BaseSymbol
+	}
+	if pair.QuoteSymbol != "" {
+		return pair.QuoteSymbol
+	}
+	return def
+}
+
+// safeCall 部分交易所接口尚未实现(panic), 转换为错误避免采集中断
+func safeCall(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(fmt.Sprint(r))
+		}
+	}()
+	return fn()
+}
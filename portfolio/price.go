@@ -0,0 +1,135 @@
+package portfolio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shadowors/goex/v2"
+)
+
+// PriceSource 资产对计价币的价格
+type PriceSource interface {
+	Price(asset, quote string) (float64, error)
+}
+
+// DefaultPegged 默认视为1:1锚定美元的稳定币
+var DefaultPegged = map[string]float64{
+	"USD":   1,
+	"USDT":  1,
+	"USDC":  1,
+	"BUSD":  1,
+	"FDUSD": 1,
+}
+
+// TickerPriceSource 使用交易所ticker最新价换算, 找不到交易对时尝试反向交易对.
+// 同一轮采集中价格缓存TTL时间, 避免重复请求ticker
+type TickerPriceSource struct {
+	pubs   []goex.IPubRest
+	pegged map[string]float64
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedPrice
+}
+
+type cachedPrice struct {
+	px float64
+	ts time.Time
+}
+
+// NewTickerPriceSource 按顺序尝试pubs, 第一个能返回价格的交易所生效
+func NewTickerPriceSource(pubs ...goex.IPubRest) *TickerPriceSource {
+	return &TickerPriceSource{
+		pubs:   pubs,
+		pegged: DefaultPegged,
+		ttl:    5 * time.Second,
+		cache:  make(map[string]cachedPrice, 16),
+	}
+}
+
+// WithPegged 替换锚定币列表, value为对美元的价格
+func (s *TickerPriceSource) WithPegged(pegged map[string]float64) *TickerPriceSource {
+	s.pegged = pegged
+	return s
+}
+
+func (s *TickerPriceSource) WithTTL(ttl time.Duration) *TickerPriceSource {
+	s.ttl = ttl
+	return s
+}
+
+func (s *TickerPriceSource) Price(asset, quote string) (float64, error) {
+	if asset == quote {
+		return 1, nil
+	}
+
+	//锚定币之间直接换算
+	if a, ok := s.pegged[asset]; ok {
+		if q, ok := s.pegged[quote]; ok && q > 0 {
+			return a / q, nil
+		}
+	}
+
+	key := asset + "/" + quote
+	s.mu.Lock()
+	c, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && time.Since(c.ts) < s.ttl {
+		return c.px, nil
+	}
+
+	px, err := s.fetch(asset, quote)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cachedPrice{px: px, ts: time.Now()}
+	s.mu.Unlock()
+
+	return px, nil
+}
+
+func (s *TickerPriceSource) fetch(asset, quote string) (float64, error) {
+	for _, pub := range s.pubs {
+		if px := s.last(pub, asset, quote); px > 0 {
+			return px, nil
+		}
+		if px := s.last(pub, quote, asset); px > 0 {
+			return 1 / px, nil
+		}
+	}
+
+	//quote为锚定币时, 尝试通过其他锚定币中转, 如: BTC->USDT 换算为 USD
+	if q, ok := s.pegged[quote]; ok && q > 0 {
+		for peg, p := range s.pegged {
+			if peg == quote {
+				continue
+			}
+			for _, pub := range s.pubs {
+				if px := s.last(pub, asset, peg); px > 0 {
+					return px * p / q, nil
+				}
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("portfolio: no price for %s/%s", asset, quote)
+}
+
+func (s *TickerPriceSource) last(pub goex.IPubRest, base, quote string) (px float64) {
+	_ = safeCall(func() error {
+		pair, err := pub.NewCurrencyPair(base, quote)
+		if err != nil || pair.Symbol == "" {
+			return err
+		}
+		ticker, _, err := pub.GetTicker(pair)
+		if err != nil || ticker == nil {
+			return err
+		}
+		px = ticker.Last
+		return nil
+	})
+	return px
+}
@@ -0,0 +1,215 @@
+package goex
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	binancefapi "github.com/shadowors/goex/v2/binance/futures/fapi"
+	binancespot "github.com/shadowors/goex/v2/binance/spot"
+	huobifutures "github.com/shadowors/goex/v2/huobi/futures"
+	huobispot "github.com/shadowors/goex/v2/huobi/spot"
+	"github.com/shadowors/goex/v2/model"
+	okxcommon "github.com/shadowors/goex/v2/okx/common"
+	okxfutures "github.com/shadowors/goex/v2/okx/futures"
+	okxspot "github.com/shadowors/goex/v2/okx/spot"
+	"github.com/shadowors/goex/v2/options"
+)
+
+// 编译期检查各交易所实现的接口
+var (
+	_ IFuturesPubRest = (*okxfutures.Swap)(nil)
+	_ IFuturesPubRest = (*binancefapi.FApi)(nil)
+	_ IFuturesPubRest = (*huobifutures.USDTSwap)(nil)
+	_ IPubRest        = (*okxspot.Spot)(nil)
+	_ IPubRest        = (*okxfutures.Futures)(nil)
+	_ IPubRest        = (*binancespot.Spot)(nil)
+	_ IPubRest        = (*huobispot.Spot)(nil)
+
+	_ ISpotPrvRest    = (*okxspot.PrvApi)(nil)
+	_ ISpotPrvRest    = (*binancespot.PrvApi)(nil)
+	_ IFuturesPrvRest = (*okxfutures.PrvApi)(nil)
+	_ IFuturesPrvRest = (*binancefapi.Prv)(nil)
+	_ IFuturesPrvRest = (*huobifutures.USDTSwapPrvApi)(nil)
+
+	_ IAlgoOrderRest  = (*okxcommon.Prv)(nil)
+	_ IAlgoOrderRest  = (*binancespot.PrvApi)(nil)
+	_ IAlgoOrderRest  = (*binancefapi.Prv)(nil)
+	_ IAlgoOrderRest  = (*huobifutures.USDTSwapPrvApi)(nil)
+	_ ICancelAllAfter = (*okxcommon.Prv)(nil)
+	_ ICancelAllAfter = (*binancefapi.Prv)(nil)
+	_ ITransferRest   = (*okxcommon.Prv)(nil)
+	_ ITransferRest   = (*binancespot.PrvApi)(nil)
+	_ ITransferRest   = (*huobifutures.USDTSwapPrvApi)(nil)
+)
+
+// PubFactory 每次调用创建新的实例, uriOpts用于覆盖endpoint等
+type PubFactory func(uriOpts ...options.UriOption) IPubRest
+
+// PrvFactory uriOpts同PubFactory
+type PrvFactory func(uriOpts []options.UriOption, apiOpts ...options.ApiOption) IPrvRest
+
+// Venue 一个交易所的一个市场, 如binance.usdm
+type Venue struct {
+	NewPub PubFactory
+	NewPrv PrvFactory //nil表示不支持私有接口
+	// WithEnvironment 可选, 切换实盘/模拟盘/AWS
+	WithEnvironment func(env options.Environment) options.UriOption
+}
+
+var (
+	venuesMu sync.RWMutex
+	venues   = make(map[string]Venue, 8)
+)
+
+// Register 注册交易所市场, 第三方包可在init中注册新的交易所:
+//
+//	func init() {
+//		goex.Register("foo.spot", goex.Venue{NewPub: ..., NewPrv: ...})
+//	}
+//
+// 名称不区分大小写, 重复注册或NewPub为nil时panic
+func Register(name string, venue Venue) {
+	name = strings.ToLower(name)
+	if name == "" || venue.NewPub == nil {
+		panic("goex: Register venue " + name + " with empty name or nil NewPub")
+	}
+
+	venuesMu.Lock()
+	defer venuesMu.Unlock()
+
+	if _, ok := venues[name]; ok {
+		panic("goex: Register called twice for venue " + name)
+	}
+	venues[name] = venue
+}
+
+// LookupVenue 按名称查找已注册的交易所市场
+func LookupVenue(name string) (Venue, bool) {
+	venuesMu.RLock()
+	defer venuesMu.RUnlock()
+	venue, ok := venues[strings.ToLower(name)]
+	return venue, ok
+}
+
+// Venues 已注册的名称, 升序
+func Venues() []string {
+	venuesMu.RLock()
+	defer venuesMu.RUnlock()
+	names := make([]string, 0, len(venues))
+	for name := range venues {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// NewPub 按名称创建公共接口实例, 如: goex.NewPub(model.BINANCE_USDM)
+func NewPub(name string, uriOpts ...options.UriOption) (IPubRest, error) {
+	venue, ok := LookupVenue(name)
+	if !ok {
+		return nil, fmt.Errorf("venue %s not registered", name)
+	}
+	return venue.NewPub(uriOpts...), nil
+}
+
+// NewPrv 按名称创建私有接口实例
+//
+//	prv, err := goex.NewPrv(model.OKX_SWAP, nil, options.WithApiKey(key), options.WithApiSecretKey(secret), options.WithPassphrase(passphrase))
+func NewPrv(name string, uriOpts []options.UriOption, apiOpts ...options.ApiOption) (IPrvRest, error) {
+	venue, ok := LookupVenue(name)
+	if !ok {
+		return nil, fmt.Errorf("venue %s not registered", name)
+	}
+	if venue.NewPrv == nil {
+		return nil, fmt.Errorf("venue %s not support private api", name)
+	}
+	return venue.NewPrv(uriOpts, apiOpts...), nil
+}
+
+func init() {
+	Register(model.OKX_SPOT, Venue{
+		NewPub: func(uriOpts ...options.UriOption) IPubRest {
+			api := okxspot.New()
+			api.WithUriOption(uriOpts...)
+			return api
+		},
+		NewPrv: func(uriOpts []options.UriOption, apiOpts ...options.ApiOption) IPrvRest {
+			api := okxspot.New()
+			api.WithUriOption(uriOpts...)
+			return api.NewPrvApi(apiOpts...)
+		},
+		WithEnvironment: okxcommon.WithEnvironment,
+	})
+
+	Register(model.OKX_FUTURES, Venue{
+		NewPub: func(uriOpts ...options.UriOption) IPubRest {
+			api := okxfutures.New()
+			api.WithUriOption(uriOpts...)
+			return api
+		},
+		NewPrv: func(uriOpts []options.UriOption, apiOpts ...options.ApiOption) IPrvRest {
+			api := okxfutures.New()
+			api.WithUriOption(uriOpts...)
+			return api.NewPrvApi(apiOpts...)
+		},
+		WithEnvironment: okxcommon.WithEnvironment,
+	})
+
+	Register(model.OKX_SWAP, Venue{
+		NewPub: func(uriOpts ...options.UriOption) IPubRest {
+			api := okxfutures.NewSwap()
+			api.WithUriOption(uriOpts...)
+			return api
+		},
+		NewPrv: func(uriOpts []options.UriOption, apiOpts ...options.ApiOption) IPrvRest {
+			api := okxfutures.NewSwap()
+			api.WithUriOption(uriOpts...)
+			return api.NewPrvApi(apiOpts...)
+		},
+		WithEnvironment: okxcommon.WithEnvironment,
+	})
+
+	Register(model.BINANCE_SPOT, Venue{
+		NewPub: func(uriOpts ...options.UriOption) IPubRest {
+			api := binancespot.New()
+			api.WithUriOption(uriOpts...)
+			return api
+		},
+		NewPrv: func(uriOpts []options.UriOption, apiOpts ...options.ApiOption) IPrvRest {
+			api := binancespot.New()
+			api.WithUriOption(uriOpts...)
+			return api.NewPrvApi(apiOpts...)
+		},
+		WithEnvironment: binancespot.WithEnvironment,
+	})
+
+	Register(model.BINANCE_USDM, Venue{
+		NewPub: func(uriOpts ...options.UriOption) IPubRest {
+			return binancefapi.NewFApi().WithUriOption(uriOpts...)
+		},
+		NewPrv: func(uriOpts []options.UriOption, apiOpts ...options.ApiOption) IPrvRest {
+			return binancefapi.NewFApi().WithUriOption(uriOpts...).NewPrvApi(apiOpts...)
+		},
+		WithEnvironment: binancefapi.WithEnvironment,
+	})
+
+	//huobi现货未实现私有接口
+	Register(model.HUOBI_SPOT, Venue{
+		NewPub: func(uriOpts ...options.UriOption) IPubRest {
+			return huobispot.New().WithUriOptions(uriOpts...)
+		},
+		WithEnvironment: huobispot.WithEnvironment,
+	})
+
+	Register(model.HUOBI_LINEAR_SWAP, Venue{
+		NewPub: func(uriOpts ...options.UriOption) IPubRest {
+			return huobifutures.NewUSDTSwap().WithUriOptions(uriOpts...)
+		},
+		NewPrv: func(uriOpts []options.UriOption, apiOpts ...options.ApiOption) IPrvRest {
+			return huobifutures.NewUSDTSwap().WithUriOptions(uriOpts...).NewUSDTSwapPrvApi(apiOpts...)
+		},
+		WithEnvironment: huobifutures.WithEnvironment,
+	})
+}
@@ -0,0 +1,117 @@
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shadowors/goex/v2"
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/model"
+)
+
+// DeadManSwitch 定时刷新交易所的倒计时撤单(OKX cancel-all-after / Binance countdownCancelAll),
+// 进程退出或网络中断导致未能按时刷新时, 交易所自动撤销所有挂单
+type DeadManSwitch struct {
+	api      goex.ICancelAllAfter
+	pairs    []model.CurrencyPair
+	timeout  time.Duration
+	interval time.Duration
+
+	mu     sync.Mutex
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewDeadManSwitch
+//
+//	timeout 倒计时时长, 刷新间隔默认为timeout/3
+//	pairs   按交易对生效的交易所(Binance)需要传入, 按账户生效的交易所(OKX)可不传
+func NewDeadManSwitch(api goex.ICancelAllAfter, timeout time.Duration, pairs ...model.CurrencyPair) *DeadManSwitch {
+	if len(pairs) == 0 {
+		pairs = []model.CurrencyPair{{}}
+	}
+	return &DeadManSwitch{
+		api:      api,
+		pairs:    pairs,
+		timeout:  timeout,
+		interval: timeout / 3,
+	}
+}
+
+// WithInterval 设置刷新间隔, 需小于timeout
+func (d *DeadManSwitch) WithInterval(interval time.Duration) *DeadManSwitch {
+	d.interval = interval
+	return d
+}
+
+// Arm 设置一次倒计时
+func (d *DeadManSwitch) Arm() error {
+	return d.set(d.timeout)
+}
+
+// Disarm 取消倒计时
+func (d *DeadManSwitch) Disarm() error {
+	return d.set(0)
+}
+
+// Start 立即设置倒计时, 之后后台按interval刷新
+func (d *DeadManSwitch) Start() error {
+	if err := d.Arm(); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	if d.stopCh != nil {
+		d.mu.Unlock()
+		return nil
+	}
+	d.stopCh = make(chan struct{})
+	stopCh := d.stopCh
+	d.mu.Unlock()
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				if err := d.Arm(); err != nil {
+					logger.Errorf("[dead man switch] arm error: %s", err.Error())
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop 停止刷新并取消倒计时, 正常退出时调用, 避免挂单被撤销
+func (d *DeadManSwitch) Stop() error {
+	d.mu.Lock()
+	stopCh := d.stopCh
+	d.stopCh = nil
+	d.mu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		d.wg.Wait()
+	}
+
+	return d.Disarm()
+}
+
+func (d *DeadManSwitch) set(timeout time.Duration) error {
+	var errs []error
+	for _, pair := range d.pairs {
+		if _, err := d.api.CancelAllAfter(pair, timeout); err != nil {
+			errs = append(errs, fmt.Errorf("%s cancel all after %s: %w", pair.Symbol, timeout, err))
+		}
+	}
+	return errors.Join(errs...)
+}
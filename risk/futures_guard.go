@@ -0,0 +1,48 @@
+package risk
+
+import (
+	"github.com/shadowors/goex/v2"
+	"github.com/shadowors/goex/v2/model"
+)
+
+// FuturesGuard 合约版Guard, GetPositions的返回会同步到敞口计算
+type FuturesGuard struct {
+	*Guard
+	api goex.IFuturesPrvRest
+}
+
+func NewFuturesGuard(api goex.IFuturesPrvRest, limits Limits) *FuturesGuard {
+	return &FuturesGuard{Guard: NewGuard(api, limits), api: api}
+}
+
+func (g *FuturesGuard) GetFuturesAccount(coin string) (map[string]model.FuturesAccount, []byte, error) {
+	return g.api.GetFuturesAccount(coin)
+}
+
+func (g *FuturesGuard) GetPositions(pair model.CurrencyPair, opts ...model.OptionParameter) ([]model.FuturesPosition, []byte, error) {
+	positions, responseBody, err := g.api.GetPositions(pair, opts...)
+	if err == nil {
+		g.SyncPosition(pair, positions)
+	}
+	return positions, responseBody, err
+}
+
+func (g *FuturesGuard) GetLeverage(pair model.CurrencyPair, marginMode model.MarginMode, opts ...model.OptionParameter) (float64, []byte, error) {
+	return g.api.GetLeverage(pair, marginMode, opts...)
+}
+
+func (g *FuturesGuard) SetLeverage(pair model.CurrencyPair, lever float64, marginMode model.MarginMode, opts ...model.OptionParameter) ([]byte, error) {
+	return g.api.SetLeverage(pair, lever, marginMode, opts...)
+}
+
+func (g *FuturesGuard) SetMarginMode(pair model.CurrencyPair, mode model.MarginMode, opts ...model.OptionParameter) ([]byte, error) {
+	return g.api.SetMarginMode(pair, mode, opts...)
+}
+
+func (g *FuturesGuard) SetPositionMode(mode model.PositionMode, opts ...model.OptionParameter) ([]byte, error) {
+	return g.api.SetPositionMode(mode, opts...)
+}
+
+func (g *FuturesGuard) AdjustMargin(pair model.CurrencyPair, posSide model.OrderSide, amount float64, opts ...model.OptionParameter) ([]byte, error) {
+	return g.api.AdjustMargin(pair, posSide, amount, opts...)
+}
@@ -0,0 +1,596 @@
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/shadowors/goex/v2"
+	huobifutures "github.com/shadowors/goex/v2/huobi/futures"
+	"github.com/shadowors/goex/v2/logger"
+	"github.com/shadowors/goex/v2/model"
+	okxcommon "github.com/shadowors/goex/v2/okx/common"
+	okxfutures "github.com/shadowors/goex/v2/okx/futures"
+	okxspot "github.com/shadowors/goex/v2/okx/spot"
+	"github.com/shadowors/goex/v2/util"
+)
+
+// Guard 为任意IPrvRest增加账户级风控:
+//   - 单交易对最大挂单数
+//   - 每个币种的总敞口/净敞口上限
+//   - 根据成交计算的当日已实现亏损上限
+//   - 下单频率限制
+//   - kill switch: 撤销所有挂单并拒绝新订单
+//
+// 成交数据来自经过Guard的GetOrderInfo/GetPendingOrders/GetHistoryOrders返回,
+// 使用websocket推送时调用OnOrderUpdate同步订单状态。
+// 敞口和盈亏按交易对计价币累加, 同一个Guard下的交易对应使用相同计价币。
+type Guard struct {
+	goex.IPrvRest
+
+	limits  Limits
+	feeSign float64 //Order.Fee换算为支出为正的系数, 见orderFeeSign
+
+	mu        sync.Mutex
+	killed    bool
+	orders    map[string]*trackedOrder //order id -> order
+	positions map[string]*position     //symbol -> position
+	pairs     map[string]model.CurrencyPair
+	orderTs   []time.Time
+	day       string
+	realized  float64
+	seq       int //下单前占位订单的序号
+}
+
+type trackedOrder struct {
+	pair     model.CurrencyPair
+	side     model.OrderSide
+	qty      float64
+	price    float64 //委托价格, 币本位合约换算敞口使用
+	executed float64
+	avgPx    float64
+	fee      float64
+	open     bool
+}
+
+type position struct {
+	pair  model.CurrencyPair
+	qty   float64 //带符号, 单位: 币
+	avgPx float64
+}
+
+func NewGuard(api goex.IPrvRest, limits Limits) *Guard {
+	if limits.OrderRateWindow <= 0 {
+		limits.OrderRateWindow = time.Second
+	}
+	return &Guard{
+		IPrvRest:  api,
+		limits:    limits,
+		feeSign:   orderFeeSign(api),
+		orders:    make(map[string]*trackedOrder, 64),
+		positions: make(map[string]*position, 8),
+		pairs:     make(map[string]model.CurrencyPair, 8),
+		day:       time.Now().UTC().Format("2006-01-02"),
+	}
+}
+
+func (g *Guard) CreateOrder(pair model.CurrencyPair, qty, price float64, side model.OrderSide, orderTy model.OrderType, opt ...model.OptionParameter) (*model.Order, []byte, error) {
+	g.mu.Lock()
+	key, err := g.reserve(pair, qty, price, side)
+	g.mu.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ord, responseBody, err := g.IPrvRest.CreateOrder(pair, qty, price, side, orderTy, opt...)
+
+	g.mu.Lock()
+	o := g.orders[key]
+	delete(g.orders, key)
+	if err == nil && ord != nil && ord.Id != "" {
+		g.orders[ord.Id] = o
+	}
+	g.mu.Unlock()
+
+	return ord, responseBody, err
+}
+
+func (g *Guard) CancelOrder(pair model.CurrencyPair, id string, opt ...model.OptionParameter) ([]byte, error) {
+	responseBody, err := g.IPrvRest.CancelOrder(pair, id, opt...)
+	if err == nil {
+		g.mu.Lock()
+		if o, ok := g.orders[id]; ok {
+			o.open = false
+		}
+		g.mu.Unlock()
+	}
+	return responseBody, err
+}
+
+// CreateOrders 逐个订单做风控检查, 未通过的订单不会发送到交易所
+func (g *Guard) CreateOrders(reqs []model.OrderRequest, opt ...model.OptionParameter) ([]model.OrderResult, []byte, error) {
+	var (
+		results  = make([]model.OrderResult, len(reqs))
+		accepted []int
+		keys     []string
+		sendReqs []model.OrderRequest
+	)
+
+	//占位订单计入同一批次后续订单的挂单数和敞口检查
+	g.mu.Lock()
+	for i, req := range reqs {
+		key, err := g.reserve(req.Pair, req.Qty, req.Price, req.Side)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		accepted = append(accepted, i)
+		keys = append(keys, key)
+		sendReqs = append(sendReqs, req)
+	}
+	g.mu.Unlock()
+
+	var (
+		rets         []model.OrderResult
+		responseBody []byte
+		err          error
+	)
+	if len(sendReqs) > 0 {
+		rets, responseBody, err = g.IPrvRest.CreateOrders(sendReqs, opt...)
+	}
+
+	g.mu.Lock()
+	for j, i := range accepted {
+		o := g.orders[keys[j]]
+		delete(g.orders, keys[j])
+		if j >= len(rets) {
+			results[i].Err = err
+			continue
+		}
+		results[i] = rets[j]
+		if rets[j].Err == nil && rets[j].Id != "" {
+			g.orders[rets[j].Id] = o
+		}
+	}
+	g.mu.Unlock()
+
+	return results, responseBody, err
+}
+
+func (g *Guard) CancelOrders(pair model.CurrencyPair, ids []string, opt ...model.OptionParameter) ([]model.OrderResult, []byte, error) {
+	results, responseBody, err := g.IPrvRest.CancelOrders(pair, ids, opt...)
+	g.mu.Lock()
+	for _, ret := range results {
+		if o, ok := g.orders[ret.Id]; ok && ret.Err == nil {
+			o.open = false
+		}
+	}
+	g.mu.Unlock()
+	return results, responseBody, err
+}
+
+// AmendOrder kill switch打开时拒绝改单, 增加数量时检查敞口上限
+func (g *Guard) AmendOrder(pair model.CurrencyPair, id string, newQty, newPrice float64, opt ...model.OptionParameter) (*model.AmendResult, []byte, error) {
+	g.mu.Lock()
+	if g.killed {
+		g.mu.Unlock()
+		return nil, nil, ErrKillSwitch
+	}
+	if o, ok := g.orders[id]; ok && newQty > o.qty {
+		if err := g.checkExposure(pair, newQty-o.qty, newPrice, o.side); err != nil {
+			g.mu.Unlock()
+			return nil, nil, err
+		}
+	}
+	g.mu.Unlock()
+
+	result, responseBody, err := g.IPrvRest.AmendOrder(pair, id, newQty, newPrice, opt...)
+	if err != nil || result == nil {
+		return result, responseBody, err
+	}
+
+	g.mu.Lock()
+	if o, ok := g.orders[id]; ok {
+		switch result.Mode {
+		case model.AmendMode_Native:
+			if newQty > 0 {
+				o.qty = newQty
+			}
+			if newPrice > 0 {
+				o.price = newPrice
+			}
+		case model.AmendMode_CancelReplace:
+			o.open = false
+			if ord := result.Order; ord != nil && ord.Id != "" {
+				g.orders[ord.Id] = &trackedOrder{pair: pair, side: o.side, qty: ord.Qty, price: newPrice, open: true}
+			}
+		}
+	}
+	g.mu.Unlock()
+
+	return result, responseBody, err
+}
+
+// CancelAllOrders 撤单成功后该交易对的订单都不再计入挂单数
+func (g *Guard) CancelAllOrders(pair model.CurrencyPair, opt ...model.OptionParameter) ([]byte, error) {
+	responseBody, err := g.IPrvRest.CancelAllOrders(pair, opt...)
+	if err == nil {
+		g.mu.Lock()
+		for _, o := range g.orders {
+			if o.pair.Symbol == pair.Symbol {
+				o.open = false
+			}
+		}
+		g.mu.Unlock()
+	}
+	return responseBody, err
+}
+
+func (g *Guard) GetOrderInfo(pair model.CurrencyPair, id string, opt ...model.OptionParameter) (*model.Order, []byte, error) {
+	ord, responseBody, err := g.IPrvRest.GetOrderInfo(pair, id, opt...)
+	if err == nil && ord != nil {
+		g.observe(pair, *ord)
+	}
+	return ord, responseBody, err
+}
+
+func (g *Guard) GetPendingOrders(pair model.CurrencyPair, opt ...model.OptionParameter) ([]model.Order, []byte, error) {
+	orders, responseBody, err := g.IPrvRest.GetPendingOrders(pair, opt...)
+	if err != nil {
+		return orders, responseBody, err
+	}
+
+	pending := make(map[string]bool, len(orders))
+	for _, ord := range orders {
+		pending[ord.Id] = true
+		g.observe(pair, ord)
+	}
+
+	//挂单列表为准, 不在列表中的订单已经不是挂单状态
+	g.mu.Lock()
+	for id, o := range g.orders {
+		if o.pair.Symbol == pair.Symbol && !pending[id] {
+			o.open = false
+		}
+	}
+	g.mu.Unlock()
+
+	return orders, responseBody, err
+}
+
+func (g *Guard) GetHistoryOrders(pair model.CurrencyPair, opt ...model.OptionParameter) ([]model.Order, []byte, error) {
+	orders, responseBody, err := g.IPrvRest.GetHistoryOrders(pair, opt...)
+	if err == nil {
+		for _, ord := range orders {
+			g.observe(pair, ord)
+		}
+	}
+	return orders, responseBody, err
+}
+
+// OnOrderUpdate 同步订单状态(如私有websocket推送), 用于计算成交、敞口和盈亏
+func (g *Guard) OnOrderUpdate(ord model.Order) {
+	g.observe(ord.Pair, ord)
+}
+
+// Kill 打开kill switch: 拒绝新订单, 并撤销所有已知交易对的挂单
+func (g *Guard) Kill() error {
+	g.mu.Lock()
+	g.killed = true
+	pairs := make([]model.CurrencyPair, 0, len(g.pairs))
+	for _, pair := range g.pairs {
+		pairs = append(pairs, pair)
+	}
+	g.mu.Unlock()
+
+	logger.Warnf("[risk guard] kill switch on, cancel all orders of %d symbols", len(pairs))
+
+	var errs []error
+	for _, pair := range pairs {
+		if _, err := g.CancelAllOrders(pair); err != nil {
+			errs = append(errs, fmt.Errorf("%s cancel all orders: %w", pair.Symbol, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Resume 关闭kill switch
+func (g *Guard) Resume() {
+	g.mu.Lock()
+	g.killed = false
+	g.mu.Unlock()
+}
+
+func (g *Guard) Killed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.killed
+}
+
+// RealizedPnL 当日(UTC)已实现盈亏
+func (g *Guard) RealizedPnL() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollDay()
+	return g.realized
+}
+
+// Exposure 币种的净敞口和总敞口, 单位: 币
+func (g *Guard) Exposure(asset string) (net, gross float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.exposure(asset)
+}
+
+// SyncPosition 以交易所持仓为准更新交易对的敞口
+func (g *Guard) SyncPosition(pair model.CurrencyPair, positions []model.FuturesPosition) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.pairs[pair.Symbol] = pair
+	pos := g.position(pair)
+	pos.qty = 0
+	for _, p := range positions {
+		contracts := util.PositionQty(p)
+		pos.qty += math.Copysign(util.ContractsToQty(pair, math.Abs(contracts), p.AvgPx), contracts)
+		pos.avgPx = p.AvgPx
+	}
+}
+
+// reserve 风控检查通过后插入占位订单, 返回占位key; 下单返回后由调用方替换为交易所订单ID. 调用方需持有锁
+func (g *Guard) reserve(pair model.CurrencyPair, qty, price float64, side model.OrderSide) (string, error) {
+	if err := g.checkOrder(pair, qty, price, side); err != nil {
+		return "", err
+	}
+
+	g.seq++
+	key := fmt.Sprintf("pending#%d", g.seq)
+	g.orders[key] = &trackedOrder{pair: pair, side: side, qty: qty, price: price, open: true}
+	g.orderTs = append(g.orderTs, time.Now())
+
+	return key, nil
+}
+
+// checkOrder 全部检查通过后才由reserve计入下单频率, 调用方需持有锁
+func (g *Guard) checkOrder(pair model.CurrencyPair, qty, price float64, side model.OrderSide) error {
+	g.pairs[pair.Symbol] = pair
+
+	if g.killed {
+		return ErrKillSwitch
+	}
+
+	now := time.Now()
+	if g.limits.MaxOrders > 0 {
+		i := 0
+		for ; i < len(g.orderTs) && now.Sub(g.orderTs[i]) >= g.limits.OrderRateWindow; i++ {
+		}
+		g.orderTs = g.orderTs[i:]
+		if len(g.orderTs) >= g.limits.MaxOrders {
+			return fmt.Errorf("%w: %d orders in %s", ErrOrderRateLimit, len(g.orderTs), g.limits.OrderRateWindow)
+		}
+	}
+
+	g.rollDay()
+	if g.limits.DailyLossLimit > 0 && g.realized <= -g.limits.DailyLossLimit {
+		return fmt.Errorf("%w: realized %v", ErrDailyLossLimit, g.realized)
+	}
+
+	if g.limits.MaxOpenOrdersPerSymbol > 0 {
+		n := 0
+		for _, o := range g.orders {
+			if o.open && o.pair.Symbol == pair.Symbol {
+				n++
+			}
+		}
+		if n >= g.limits.MaxOpenOrdersPerSymbol {
+			return fmt.Errorf("%w: %s has %d open orders", ErrMaxOpenOrders, pair.Symbol, n)
+		}
+	}
+
+	return g.checkExposure(pair, qty, price, side)
+}
+
+// checkExposure 检查加上新订单后的最坏敞口(所有挂单全部成交), 调用方需持有锁
+func (g *Guard) checkExposure(pair model.CurrencyPair, qty, price float64, side model.OrderSide) error {
+	asset := pair.BaseSymbol
+	net, gross := g.worstExposure(asset, &trackedOrder{pair: pair, side: side, qty: qty, price: price, open: true})
+
+	if maxGross, ok := g.limits.MaxGrossExposure[asset]; ok && gross > maxGross {
+		return fmt.Errorf("%w: %s %v > %v", ErrMaxGrossExpo, asset, gross, maxGross)
+	}
+
+	if maxNet, ok := g.limits.MaxNetExposure[asset]; ok && net > maxNet {
+		return fmt.Errorf("%w: %s %v > %v", ErrMaxNetExpo, asset, net, maxNet)
+	}
+
+	return nil
+}
+
+// worstExposure 持仓加上挂单剩余数量全部成交后的最坏情况, 单位: 币.
+// 净敞口取买单全部成交和卖单全部成交两种情况的较大绝对值, 总敞口按交易对分别取较大者后累加.
+// extra为尚未记录的新订单, 可为nil; 调用方需持有锁
+func (g *Guard) worstExposure(asset string, extra *trackedOrder) (net, gross float64) {
+	type symbolExpo struct{ qty, buy, sell float64 }
+	bySymbol := make(map[string]*symbolExpo, 4)
+	get := func(symbol string) *symbolExpo {
+		e, ok := bySymbol[symbol]
+		if !ok {
+			e = &symbolExpo{}
+			bySymbol[symbol] = e
+		}
+		return e
+	}
+
+	for symbol, pos := range g.positions {
+		if pos.pair.BaseSymbol == asset {
+			get(symbol).qty = pos.qty
+		}
+	}
+
+	addOrder := func(o *trackedOrder) {
+		remaining := o.qty - o.executed
+		if !o.open || remaining <= 0 || o.pair.BaseSymbol != asset {
+			return
+		}
+		e := get(o.pair.Symbol)
+		px := o.price
+		if px <= 0 { //市价单按持仓均价估算币本位合约的币数量
+			if pos, ok := g.positions[o.pair.Symbol]; ok {
+				px = pos.avgPx
+			}
+		}
+		if util.IsBuy(o.side) {
+			e.buy += util.ContractsToQty(o.pair, remaining, px)
+		} else {
+			e.sell += util.ContractsToQty(o.pair, remaining, px)
+		}
+	}
+
+	for _, o := range g.orders {
+		addOrder(o)
+	}
+	if extra != nil {
+		addOrder(extra)
+	}
+
+	var qty, buy, sell float64
+	for _, e := range bySymbol {
+		qty, buy, sell = qty+e.qty, buy+e.buy, sell+e.sell
+		gross += math.Max(math.Abs(e.qty+e.buy), math.Abs(e.qty-e.sell))
+	}
+	net = math.Max(math.Abs(qty+buy), math.Abs(qty-sell))
+
+	return
+}
+
+func (g *Guard) observe(pair model.CurrencyPair, ord model.Order) {
+	if ord.Id == "" {
+		return
+	}
+
+	if ord.Pair.Symbol != "" {
+		pair = ord.Pair
+	}
+
+	g.mu.Lock()
+
+	g.pairs[pair.Symbol] = pair
+	o, ok := g.orders[ord.Id]
+	if !ok {
+		//不是经过Guard创建的订单, 以首次看到的成交量为基准, 不计入历史成交
+		o = &trackedOrder{pair: pair, side: ord.Side, qty: ord.Qty,
+			executed: ord.ExecutedQty, avgPx: ord.PriceAvg, fee: ord.Fee}
+		g.orders[ord.Id] = o
+	}
+
+	if delta := ord.ExecutedQty - o.executed; delta > 0 {
+		px := ord.PriceAvg
+		if px <= 0 {
+			px = ord.Price
+		} else if o.executed > 0 {
+			px = (ord.PriceAvg*ord.ExecutedQty - o.avgPx*o.executed) / delta
+		}
+		g.applyFill(pair, o.side, delta, px)
+		o.executed = ord.ExecutedQty
+		o.avgPx = ord.PriceAvg
+	}
+
+	if feeDelta := ord.Fee - o.fee; feeDelta != 0 {
+		if ord.FeeCcy == "" || ord.FeeCcy == pair.QuoteSymbol || ord.FeeCcy == pair.SettlementCurrency {
+			g.realized -= feeDelta * g.feeSign
+		}
+		o.fee = ord.Fee
+	}
+
+	switch ord.Status {
+	case model.OrderStatus_Pending, model.OrderStatus_PartFinished:
+		o.open = true
+	case model.OrderStatus_Finished, model.OrderStatus_Canceled:
+		o.open = false
+	}
+
+	needKill := g.limits.KillOnDailyLoss && !g.killed &&
+		g.limits.DailyLossLimit > 0 && g.realized <= -g.limits.DailyLossLimit
+
+	g.mu.Unlock()
+
+	if needKill {
+		logger.Errorf("[risk guard] daily loss limit %v reached", g.limits.DailyLossLimit)
+		if err := g.Kill(); err != nil {
+			logger.Errorf("[risk guard] kill error: %s", err.Error())
+		}
+	}
+}
+
+// applyFill 按平均成本法更新持仓和已实现盈亏, 调用方需持有锁
+func (g *Guard) applyFill(pair model.CurrencyPair, side model.OrderSide, qty, px float64) {
+	g.rollDay()
+
+	pos := g.position(pair)
+	fillQty := util.ContractsToQty(pair, qty, px)
+	signed := fillQty
+	if !util.IsBuy(side) {
+		signed = -signed
+	}
+
+	if pos.qty == 0 || (pos.qty > 0) == (signed > 0) {
+		pos.avgPx = (pos.avgPx*math.Abs(pos.qty) + px*fillQty) / (math.Abs(pos.qty) + fillQty)
+		pos.qty += signed
+		return
+	}
+
+	closeQty := math.Min(fillQty, math.Abs(pos.qty))
+	if pos.qty > 0 {
+		g.realized += (px - pos.avgPx) * closeQty
+	} else {
+		g.realized += (pos.avgPx - px) * closeQty
+	}
+
+	pos.qty += signed
+	if fillQty > closeQty { //反手
+		pos.avgPx = px
+	}
+	if pos.qty == 0 {
+		pos.avgPx = 0
+	}
+}
+
+func (g *Guard) position(pair model.CurrencyPair) *position {
+	pos, ok := g.positions[pair.Symbol]
+	if !ok {
+		pos = &position{pair: pair}
+		g.positions[pair.Symbol] = pos
+	}
+	return pos
+}
+
+func (g *Guard) exposure(asset string) (net, gross float64) {
+	for _, pos := range g.positions {
+		if pos.pair.BaseSymbol == asset {
+			net += pos.qty
+			gross += math.Abs(pos.qty)
+		}
+	}
+	return
+}
+
+func (g *Guard) rollDay() {
+	day := time.Now().UTC().Format("2006-01-02")
+	if day != g.day {
+		g.day = day
+		g.realized = 0
+	}
+}
+
+// orderFeeSign okx和火币订单的fee为负表示扣除, 正数为返佣; 其他交易所按正数为支出处理
+func orderFeeSign(api goex.IPrvRest) float64 {
+	switch api.(type) {
+	case *okxspot.PrvApi, *okxfutures.PrvApi, *okxfutures.IsolatedPrvApi, *okxfutures.CrossPrvApi, *okxcommon.Prv,
+		*huobifutures.USDTSwapPrvApi:
+		return -1
+	}
+	return 1
+}
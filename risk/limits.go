@@ -0,0 +1,26 @@
+package risk
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrKillSwitch     = errors.New("risk: kill switch is on, new orders are blocked")
+	ErrMaxOpenOrders  = errors.New("risk: max open orders per symbol exceeded")
+	ErrMaxGrossExpo   = errors.New("risk: max gross exposure exceeded")
+	ErrMaxNetExpo     = errors.New("risk: max net exposure exceeded")
+	ErrDailyLossLimit = errors.New("risk: daily loss limit reached")
+	ErrOrderRateLimit = errors.New("risk: order rate limit exceeded")
+)
+
+// Limits 账户级风控参数, 零值表示不限制
+type Limits struct {
+	MaxOpenOrdersPerSymbol int                //单个交易对最大挂单数
+	MaxGrossExposure       map[string]float64 //每个币种最大总敞口(多+空), 单位: 币
+	MaxNetExposure         map[string]float64 //每个币种最大净敞口(|多-空|), 单位: 币
+	DailyLossLimit         float64            //当日(UTC)最大已实现亏损, 单位: 计价币, 正数
+	MaxOrders              int                //OrderRateWindow时间内最多下单次数
+	OrderRateWindow        time.Duration      //下单频率统计窗口, 默认1s
+	KillOnDailyLoss        bool               //达到当日亏损限制时自动触发kill switch撤销所有挂单
+}
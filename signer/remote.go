@@ -0,0 +1,346 @@
+package signer
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/shadowors/goex/v2/logger"
+)
+
+// 协议: 每行一个json, 客户端发送signRequest, 服务端返回signResponse, 同一连接上按顺序处理
+type signRequest struct {
+	KeyId   string `json:"key_id"`
+	Payload string `json:"payload"`
+	Token   string `json:"token,omitempty"` //tcp模式的共享令牌
+}
+
+type signResponse struct {
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ErrTokenRequired tcp模式没有设置共享令牌
+var ErrTokenRequired = errors.New("signer server: tcp listener requires a token")
+
+// RemoteSigner 通过unix socket(或tcp)请求独立的签名进程, 本进程不持有密钥
+type RemoteSigner struct {
+	network string
+	addr    string
+	keyId   string
+	keyType KeyType
+	token   string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+	enc  *json.Encoder
+	dec  *json.Decoder
+}
+
+// NewRemoteSigner
+//
+//	network unix或tcp
+//	keyId   签名服务中的密钥ID, 见Server
+func NewRemoteSigner(network, addr, keyId string) *RemoteSigner {
+	return &RemoteSigner{network: network, addr: addr, keyId: keyId, timeout: 3 * time.Second}
+}
+
+// WithTimeout 单次签名超时, 默认3s
+func (s *RemoteSigner) WithTimeout(timeout time.Duration) *RemoteSigner {
+	s.timeout = timeout
+	return s
+}
+
+// WithKeyType 签名服务中keyId对应的密钥类型, 交易所限制密钥类型时需要设置, 见KeyTyper
+func (s *RemoteSigner) WithKeyType(keyType KeyType) *RemoteSigner {
+	s.keyType = keyType
+	return s
+}
+
+func (s *RemoteSigner) KeyType() KeyType {
+	return s.keyType
+}
+
+// WithToken 签名服务的共享令牌, tcp模式必须设置, 与Server.WithToken相同
+func (s *RemoteSigner) WithToken(token string) *RemoteSigner {
+	s.token = token
+	return s
+}
+
+// Sign 连接断开时重连重试一次
+func (s *RemoteSigner) Sign(payload string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sign, err := s.sign(payload)
+	if err == nil || !s.closeOnNetErr(err) {
+		return sign, err
+	}
+
+	return s.sign(payload)
+}
+
+func (s *RemoteSigner) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *RemoteSigner) sign(payload string) (string, error) {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.addr, s.timeout)
+		if err != nil {
+			return "", err
+		}
+		s.conn, s.enc, s.dec = conn, json.NewEncoder(conn), json.NewDecoder(conn)
+	}
+
+	_ = s.conn.SetDeadline(time.Now().Add(s.timeout))
+
+	if err := s.enc.Encode(signRequest{KeyId: s.keyId, Payload: payload, Token: s.token}); err != nil {
+		return "", err
+	}
+
+	var resp signResponse
+	if err := s.dec.Decode(&resp); err != nil {
+		return "", err
+	}
+
+	if resp.Error != "" {
+		return "", &RemoteError{Msg: resp.Error}
+	}
+
+	return resp.Signature, nil
+}
+
+// closeOnNetErr 非签名服务返回的错误都视为连接异常, 关闭连接以便重连
+func (s *RemoteSigner) closeOnNetErr(err error) bool {
+	var remoteErr *RemoteError
+	if errors.As(err, &remoteErr) {
+		return false
+	}
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	return true
+}
+
+// RemoteError 签名服务返回的错误
+type RemoteError struct {
+	Msg string
+}
+
+func (e *RemoteError) Error() string {
+	return "remote signer: " + e.Msg
+}
+
+// Server 签名服务, 运行在持有密钥的独立进程中. unix socket依靠文件权限限制访问,
+// tcp没有其他认证方式, 必须设置共享令牌; 令牌明文传输, tcp只应在本机或可信网络中使用
+//
+//	srv := signer.NewServer(map[string]signer.Signer{
+//		"okx-main":     signer.NewHmacSHA256Base64(secret),
+//		"binance-main": ed25519Signer,
+//	})
+//	srv.ListenAndServe("unix", "/run/goex/signer.sock")
+//	srv.WithToken(token).ListenAndServe("tcp", "127.0.0.1:7001")
+type Server struct {
+	signers map[string]Signer
+	token   string
+
+	mu       sync.Mutex
+	closed   bool
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+func NewServer(signers map[string]Signer) *Server {
+	return &Server{signers: signers, conns: make(map[net.Conn]struct{}, 4)}
+}
+
+// WithToken 设置共享令牌后所有请求都需要携带相同令牌
+func (srv *Server) WithToken(token string) *Server {
+	srv.token = token
+	return srv
+}
+
+// ListenAndServe unix socket文件权限为0600, 仅允许同一用户的进程连接; 其他网络需要先设置WithToken
+func (srv *Server) ListenAndServe(network, addr string) error {
+	if network != "unix" && srv.token == "" {
+		return ErrTokenRequired
+	}
+
+	var (
+		l   net.Listener
+		err error
+	)
+	if network == "unix" {
+		l, err = listenUnix(addr)
+	} else {
+		l, err = net.Listen(network, addr)
+	}
+	if err != nil {
+		return err
+	}
+
+	return srv.Serve(l)
+}
+
+// listenUnix 先在0700的临时目录中创建socket并设置为0600, 再rename到addr,
+// 避免创建到chmod之间其他用户连接; addr已存在且不是socket时返回错误
+func listenUnix(addr string) (net.Listener, error) {
+	if fi, err := os.Lstat(addr); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("signer server: %s exists and is not a socket", addr)
+		}
+		if err = os.Remove(addr); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(addr), ".signer-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	tmp := filepath.Join(dir, "sock")
+	l, err := net.Listen("unix", tmp)
+	if err != nil {
+		return nil, err
+	}
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	if err = os.Chmod(tmp, 0600); err == nil {
+		err = os.Rename(tmp, addr)
+	}
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	return &unixListener{Listener: l, path: addr}, nil
+}
+
+// unixListener 关闭时删除rename后的socket文件
+type unixListener struct {
+	net.Listener
+	path string
+}
+
+func (l *unixListener) Close() error {
+	err := l.Listener.Close()
+	_ = os.Remove(l.path)
+	return err
+}
+
+// Serve 阻塞直到Close, 非unix socket的listener需要先设置WithToken
+func (srv *Server) Serve(l net.Listener) error {
+	if l.Addr().Network() != "unix" && srv.token == "" {
+		return ErrTokenRequired
+	}
+
+	srv.mu.Lock()
+	if srv.closed {
+		srv.mu.Unlock()
+		return l.Close()
+	}
+	srv.listener = l
+	srv.mu.Unlock()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		//与Close互斥: 关闭后接受的连接直接关闭, wg.Add不会与wg.Wait并发
+		srv.mu.Lock()
+		if srv.closed {
+			srv.mu.Unlock()
+			conn.Close()
+			continue
+		}
+		srv.conns[conn] = struct{}{}
+		srv.wg.Add(1)
+		srv.mu.Unlock()
+
+		go srv.serveConn(conn)
+	}
+}
+
+func (srv *Server) Close() error {
+	srv.mu.Lock()
+	srv.closed = true
+	var err error
+	if srv.listener != nil {
+		err = srv.listener.Close()
+	}
+	for conn := range srv.conns {
+		conn.Close()
+	}
+	srv.mu.Unlock()
+
+	srv.wg.Wait()
+	return err
+}
+
+func (srv *Server) serveConn(conn net.Conn) {
+	defer func() {
+		srv.mu.Lock()
+		delete(srv.conns, conn)
+		srv.mu.Unlock()
+		conn.Close()
+		srv.wg.Done()
+	}()
+
+	var (
+		dec = json.NewDecoder(conn)
+		enc = json.NewEncoder(conn)
+	)
+
+	for {
+		var req signRequest
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+
+		var resp signResponse
+		if srv.token != "" && subtle.ConstantTimeCompare([]byte(req.Token), []byte(srv.token)) != 1 {
+			//令牌错误时返回错误并断开连接
+			_ = enc.Encode(signResponse{Error: "invalid token"})
+			return
+		}
+
+		if s, ok := srv.signers[req.KeyId]; !ok {
+			resp.Error = fmt.Sprintf("key %s not found", req.KeyId)
+		} else if sign, err := s.Sign(req.Payload); err != nil {
+			resp.Error = err.Error()
+		} else {
+			resp.Signature = sign
+		}
+
+		if err := enc.Encode(resp); err != nil {
+			logger.Warnf("[signer server] write response error: %s", err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/shadowors/goex/v2/util"
+)
+
+// Signer 对待签名字符串签名, 返回交易所要求编码(hex/base64)的签名.
+// 签名算法和编码由Signer自身决定, 调用方只提供payload, 远程签名时策略进程不持有任何密钥
+type Signer interface {
+	Sign(payload string) (string, error)
+}
+
+// KeyType 签名密钥类型
+type KeyType string
+
+const (
+	KeyType_Unknown KeyType = ""
+	KeyType_HMAC    KeyType = "hmac"
+	KeyType_Ed25519 KeyType = "ed25519"
+	KeyType_RSA     KeyType = "rsa"
+)
+
+// KeyTyper 可选接口, 交易所限制密钥类型时(如binance session.logon只支持Ed25519)用于提前校验
+type KeyTyper interface {
+	KeyType() KeyType
+}
+
+// TypeOf Signer的密钥类型, 没有实现KeyTyper时返回KeyType_Unknown
+func TypeOf(s Signer) KeyType {
+	if kt, ok := s.(KeyTyper); ok {
+		return kt.KeyType()
+	}
+	return KeyType_Unknown
+}
+
+// SignerFunc 函数适配为Signer
+type SignerFunc func(payload string) (string, error)
+
+func (f SignerFunc) Sign(payload string) (string, error) {
+	return f(payload)
+}
+
+// HmacSigner HMAC-SHA256, okx/huobi使用base64编码, binance使用hex编码
+type HmacSigner struct {
+	secret string
+	base64 bool
+}
+
+// NewHmacSHA256Hex binance
+func NewHmacSHA256Hex(secret string) *HmacSigner {
+	return &HmacSigner{secret: secret}
+}
+
+// NewHmacSHA256Base64 okx, huobi
+func NewHmacSHA256Base64(secret string) *HmacSigner {
+	return &HmacSigner{secret: secret, base64: true}
+}
+
+func (s *HmacSigner) Sign(payload string) (string, error) {
+	if s.base64 {
+		return util.HmacSHA256Base64Sign(s.secret, payload)
+	}
+	return util.HmacSHA256Sign(s.secret, payload)
+}
+
+func (s *HmacSigner) KeyType() KeyType {
+	return KeyType_HMAC
+}
+
+// Ed25519Signer 返回base64
+type Ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+func NewEd25519Signer(key ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{key: key}
+}
+
+func (s *Ed25519Signer) Sign(payload string) (string, error) {
+	return util.Ed25519Base64Sign(s.key, payload), nil
+}
+
+func (s *Ed25519Signer) KeyType() KeyType {
+	return KeyType_Ed25519
+}
+
+// RSASigner RSASSA-PKCS1-v1_5 + SHA256, 返回base64
+type RSASigner struct {
+	key *rsa.PrivateKey
+}
+
+func NewRSASigner(key *rsa.PrivateKey) *RSASigner {
+	return &RSASigner{key: key}
+}
+
+func (s *RSASigner) Sign(payload string) (string, error) {
+	return util.RSASHA256Base64Sign(s.key, payload)
+}
+
+func (s *RSASigner) KeyType() KeyType {
+	return KeyType_RSA
+}
+
+// NewKeySigner 按PEM私钥类型创建Ed25519Signer或RSASigner
+func NewKeySigner(pemData []byte) (Signer, error) {
+	key, err := util.ParsePrivateKeyPEM(pemData)
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return NewEd25519Signer(k), nil
+	case *rsa.PrivateKey:
+		return NewRSASigner(k), nil
+	}
+
+	return nil, fmt.Errorf("unsupported private key type %T", key)
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shadowors/goex/v2/model"
+)
+
+var ErrOrderNotAmendable = errors.New("order is not amendable")
+
+// CancelReplaceApi 撤单重下需要的接口
+type CancelReplaceApi interface {
+	GetOrderInfo(pair model.CurrencyPair, id string, opt ...model.OptionParameter) (*model.Order, []byte, error)
+	CancelOrder(pair model.CurrencyPair, id string, opt ...model.OptionParameter) ([]byte, error)
+	CreateOrder(pair model.CurrencyPair, qty, price float64, side model.OrderSide, orderTy model.OrderType, opt ...model.OptionParameter) (*model.Order, []byte, error)
+}
+
+// CancelReplaceOrder 不支持原生改单的交易所使用: 查询原订单 -> 撤单 -> 按剩余数量(newQty-已成交)重新下单.
+// opt只用于新订单, 如: 新的OrderClientID
+func CancelReplaceOrder(api CancelReplaceApi, pair model.CurrencyPair, id string, newQty, newPrice float64, opt ...model.OptionParameter) (*model.AmendResult, []byte, error) {
+	orig, responseBody, err := api.GetOrderInfo(pair, id)
+	if err != nil {
+		return nil, responseBody, err
+	}
+	if orig == nil {
+		return nil, responseBody, fmt.Errorf("%w: order %s not found", ErrOrderNotAmendable, id)
+	}
+
+	switch orig.Status {
+	case model.OrderStatus_Finished, model.OrderStatus_Canceled, model.OrderStatus_Rejected:
+		return nil, responseBody, fmt.Errorf("%w: order %s is %s", ErrOrderNotAmendable, id, orig.Status)
+	}
+
+	responseBody, err = api.CancelOrder(pair, id)
+	if err != nil {
+		return nil, responseBody, err
+	}
+
+	//撤单后再查一次, 获取撤单前最终的成交数量
+	executedQty := orig.ExecutedQty
+	if canceled, _, err := api.GetOrderInfo(pair, id); err == nil && canceled != nil &&
+		canceled.ExecutedQty > executedQty {
+		executedQty = canceled.ExecutedQty
+	}
+
+	result := &model.AmendResult{Mode: model.AmendMode_CancelReplace, OrigId: id}
+
+	if newQty <= 0 {
+		newQty = orig.Qty
+	}
+	if newPrice <= 0 {
+		newPrice = orig.Price
+	}
+
+	remaining := newQty - executedQty
+	if remaining <= 0 {
+		return result, responseBody, fmt.Errorf("%w: order %s executed %v >= new qty %v", ErrOrderNotAmendable, id, executedQty, newQty)
+	}
+
+	ord, responseBody, err := api.CreateOrder(pair, remaining, newPrice, orig.Side, orig.OrderTy, opt...)
+	if err != nil {
+		return result, responseBody, err
+	}
+
+	result.Order = ord
+	return result, responseBody, nil
+}
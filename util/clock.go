@@ -0,0 +1,137 @@
+package util
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shadowors/goex/v2/logger"
+)
+
+// ServerTimeFunc 返回交易所服务器时间(毫秒)
+type ServerTimeFunc func() (int64, error)
+
+// Clock 估算本地时钟与交易所服务器时钟的偏差, 签名时使用校正后的时间.
+// 每次同步采样多次, 取往返耗时最短的一次, 以请求发出与收到响应的中点作为服务器时间对应的本地时间
+type Clock struct {
+	fetch   ServerTimeFunc
+	samples int
+
+	offset atomic.Int64 //服务器时间-本地时间, 纳秒
+	rtt    atomic.Int64 //最近一次同步的最短往返耗时, 纳秒
+
+	mu     sync.Mutex
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewClock(fetch ServerTimeFunc) *Clock {
+	return &Clock{fetch: fetch, samples: 5}
+}
+
+// WithSamples 设置每次同步的采样次数, 默认5次
+func (c *Clock) WithSamples(n int) *Clock {
+	c.samples = max(n, 1)
+	return c
+}
+
+// Now 校正后的当前时间, 未同步时等同于time.Now()
+func (c *Clock) Now() time.Time {
+	return time.Now().Add(c.Offset())
+}
+
+// Offset 服务器时间-本地时间
+func (c *Clock) Offset() time.Duration {
+	return time.Duration(c.offset.Load())
+}
+
+// RTT 最近一次同步的最短往返耗时
+func (c *Clock) RTT() time.Duration {
+	return time.Duration(c.rtt.Load())
+}
+
+// Sync 同步一次时钟偏差, 所有采样都失败时返回最后一个错误
+func (c *Clock) Sync() error {
+	var (
+		bestRtt time.Duration = math.MaxInt64
+		offset  time.Duration
+		lastErr error
+	)
+
+	for i := 0; i < c.samples; i++ {
+		t0 := time.Now()
+		serverTs, err := c.fetch()
+		t1 := time.Now()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		rtt := t1.Sub(t0)
+		if rtt < bestRtt {
+			bestRtt = rtt
+			offset = time.UnixMilli(serverTs).Sub(t0.Add(rtt / 2))
+		}
+	}
+
+	if bestRtt == math.MaxInt64 {
+		if lastErr == nil {
+			lastErr = errors.New("no server time sample")
+		}
+		return lastErr
+	}
+
+	c.offset.Store(int64(offset))
+	c.rtt.Store(int64(bestRtt))
+	logger.Debugf("[clock] offset: %s, rtt: %s", offset, bestRtt)
+
+	return nil
+}
+
+// Start 立即同步一次, 之后后台按interval定时同步; 首次同步失败时仍会启动后台同步
+func (c *Clock) Start(interval time.Duration) error {
+	err := c.Sync()
+
+	c.mu.Lock()
+	if c.stopCh != nil {
+		c.mu.Unlock()
+		return err
+	}
+	c.stopCh = make(chan struct{})
+	stopCh := c.stopCh
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				if err := c.Sync(); err != nil {
+					logger.Warnf("[clock] sync server time error: %s", err.Error())
+				}
+			}
+		}
+	}()
+
+	return err
+}
+
+// Stop 停止后台同步, 保留最后一次的偏差
+func (c *Clock) Stop() {
+	c.mu.Lock()
+	stopCh := c.stopCh
+	c.stopCh = nil
+	c.mu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		c.wg.Wait()
+	}
+}
@@ -0,0 +1,78 @@
+package util
+
+import (
+	"math"
+
+	"github.com/shadowors/goex/v2/model"
+)
+
+// 合约数量换算, 按CurrencyPair.ContractVal/ContractValCurrency区分:
+//   - 现货(ContractVal为0): 数量即币数量
+//   - U本位(面值为标的币, 如okx BTC-USDT-SWAP 0.01BTC/张, binance USDⓈ-M 1BTC/张): 币数量 = 张数 * 面值
+//   - 币本位(面值为计价币, 如okx BTC-USD-SWAP 100USD/张): 币数量 = 张数 * 面值 / 价格
+
+// IsQuoteValued 合约面值以计价币(USD)表示, 即币本位合约, 换算币数量需要价格
+func IsQuoteValued(pair model.CurrencyPair) bool {
+	return pair.ContractVal > 0 && pair.ContractValCurrency != "" && pair.ContractValCurrency != pair.BaseSymbol
+}
+
+// ContractsToQty 张数换算成币数量, 币本位合约price<=0时返回0
+func ContractsToQty(pair model.CurrencyPair, contracts, price float64) float64 {
+	if pair.ContractVal <= 0 {
+		return contracts
+	}
+	if IsQuoteValued(pair) {
+		if price <= 0 {
+			return 0
+		}
+		return contracts * pair.ContractVal / price
+	}
+	return contracts * pair.ContractVal
+}
+
+// QtyToContracts 币数量换算成张数, 未按LotSize取整; 币本位合约price<=0时返回0
+func QtyToContracts(pair model.CurrencyPair, qty, price float64) float64 {
+	if pair.ContractVal <= 0 {
+		return qty
+	}
+	if IsQuoteValued(pair) {
+		return qty * price / pair.ContractVal
+	}
+	return qty / pair.ContractVal
+}
+
+// ContractsToNotional 张数换算成计价币金额
+func ContractsToNotional(pair model.CurrencyPair, contracts, price float64) float64 {
+	if IsQuoteValued(pair) {
+		return contracts * pair.ContractVal
+	}
+	return ContractsToQty(pair, contracts, price) * price
+}
+
+// NotionalToContracts 计价币金额换算成张数, 未按LotSize取整; price<=0时返回0
+func NotionalToContracts(pair model.CurrencyPair, notional, price float64) float64 {
+	if IsQuoteValued(pair) {
+		return notional / pair.ContractVal
+	}
+	if price <= 0 {
+		return 0
+	}
+	return QtyToContracts(pair, notional/price, price)
+}
+
+// PositionQty 持仓张数, 多仓为正, 空仓为负.
+// 单向持仓(okx net模式PosSide为空)时Qty本身带符号
+func PositionQty(pos model.FuturesPosition) float64 {
+	switch pos.PosSide {
+	case model.Futures_OpenBuy:
+		return math.Abs(pos.Qty)
+	case model.Futures_OpenSell:
+		return -math.Abs(pos.Qty)
+	}
+	return pos.Qty
+}
+
+// IsBuy 买入方向: 现货买入、开多、平空
+func IsBuy(side model.OrderSide) bool {
+	return side == model.Spot_Buy || side == model.Futures_OpenBuy || side == model.Futures_CloseSell
+}
@@ -0,0 +1,157 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/shadowors/goex/v2/model"
+	"github.com/spf13/cast"
+)
+
+type RoundingMode int
+
+const (
+	RoundingMode_Down    RoundingMode = iota + 1 //向零取整
+	RoundingMode_Up                              //远离零取整
+	RoundingMode_Nearest                         //四舍五入
+)
+
+var (
+	ErrInvalidPrice     = errors.New("invalid price")
+	ErrInvalidQty       = errors.New("invalid qty")
+	ErrQtyTooSmall      = errors.New("qty less than min qty")
+	ErrQtyTooLarge      = errors.New("qty greater than max qty")
+	ErrNotionalTooSmall = errors.New("notional less than min notional")
+)
+
+// stepEpsilon 用于抵消浮点除法误差, 例如 0.3/0.1 = 2.9999999999999996
+const stepEpsilon = 1e-9
+
+// PrecisionOf 根据步长字符串计算小数位数
+//
+//	"0.01" -> 2, "0.5" -> 1, "5" -> 0, "0.00100000" -> 3
+func PrecisionOf(step string) int {
+	step = strings.TrimSpace(step)
+	if strings.ContainsAny(step, "eE") {
+		step = strconv.FormatFloat(cast.ToFloat64(step), 'f', -1, 64)
+	}
+	i := strings.IndexByte(step, '.')
+	if i < 0 {
+		return 0
+	}
+	return len(strings.TrimRight(step[i+1:], "0"))
+}
+
+// RoundToStep 将v按步长step取整, step<=0时原样返回
+func RoundToStep(v, step float64, mode RoundingMode) float64 {
+	if step <= 0 || v == 0 {
+		return v
+	}
+
+	n := v / step
+	switch mode {
+	case RoundingMode_Up:
+		if n > 0 {
+			n = math.Ceil(n - stepEpsilon)
+		} else {
+			n = math.Floor(n + stepEpsilon)
+		}
+	case RoundingMode_Nearest:
+		n = math.Round(n)
+	default:
+		if n > 0 {
+			n = math.Floor(n + stepEpsilon)
+		} else {
+			n = math.Ceil(n - stepEpsilon)
+		}
+	}
+
+	prec := PrecisionOf(strconv.FormatFloat(step, 'f', -1, 64))
+	ret, _ := strconv.ParseFloat(strconv.FormatFloat(n*step, 'f', prec, 64), 64)
+	return ret
+}
+
+// OrderNormalizer 按交易对的tick size/lot size规整下单价格和数量,
+// 并在本地拒绝交易所必然拒绝的订单(数量越界、金额不足)
+type OrderNormalizer struct {
+	PriceRounding RoundingMode
+	QtyRounding   RoundingMode
+}
+
+// DefaultOrderNormalizer 价格四舍五入到tick, 数量向下取整到lot(不会超出可用余额)
+var DefaultOrderNormalizer = OrderNormalizer{
+	PriceRounding: RoundingMode_Nearest,
+	QtyRounding:   RoundingMode_Down,
+}
+
+// NormalizePrice 价格按tick size规整, 没有tick size时按PricePrecision规整
+func (n OrderNormalizer) NormalizePrice(pair model.CurrencyPair, price float64) float64 {
+	if pair.TickSize > 0 {
+		return RoundToStep(price, pair.TickSize, n.PriceRounding)
+	}
+	return RoundToStep(price, math.Pow10(-pair.PricePrecision), n.PriceRounding)
+}
+
+// NormalizeQty 数量按lot size规整, 没有lot size时按QtyPrecision规整
+func (n OrderNormalizer) NormalizeQty(pair model.CurrencyPair, qty float64) float64 {
+	if pair.LotSize > 0 {
+		return RoundToStep(qty, pair.LotSize, n.QtyRounding)
+	}
+	return RoundToStep(qty, math.Pow10(-pair.QtyPrecision), n.QtyRounding)
+}
+
+// Normalize 规整价格和数量并做本地校验
+// 市价单不校验价格, 当price<=0时不校验最小下单金额
+func (n OrderNormalizer) Normalize(pair model.CurrencyPair, qty, price float64, orderTy model.OrderType) (float64, float64, error) {
+	if qty <= 0 || math.IsNaN(qty) || math.IsInf(qty, 0) {
+		return qty, price, fmt.Errorf("%w: %v", ErrInvalidQty, qty)
+	}
+
+	if orderTy != model.OrderType_Market {
+		if price <= 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+			return qty, price, fmt.Errorf("%w: %v", ErrInvalidPrice, price)
+		}
+		price = n.NormalizePrice(pair, price)
+		if price <= 0 {
+			return qty, price, fmt.Errorf("%w: less than tick size %v", ErrInvalidPrice, pair.TickSize)
+		}
+	}
+
+	qty = n.NormalizeQty(pair, qty)
+
+	return qty, price, CheckOrderLimits(pair, qty, price, orderTy)
+}
+
+// CheckOrderLimits 校验数量上下限和最小下单金额
+func CheckOrderLimits(pair model.CurrencyPair, qty, price float64, orderTy model.OrderType) error {
+	if qty <= 0 {
+		return fmt.Errorf("%w: %v", ErrQtyTooSmall, qty)
+	}
+
+	if pair.MinQty > 0 && qty < pair.MinQty {
+		return fmt.Errorf("%w: %v < %v", ErrQtyTooSmall, qty, pair.MinQty)
+	}
+
+	maxQty := pair.MaxQty
+	if orderTy == model.OrderType_Market && pair.MarketQty > 0 {
+		maxQty = pair.MarketQty
+	}
+	if maxQty > 0 && qty > maxQty {
+		return fmt.Errorf("%w: %v > %v", ErrQtyTooLarge, qty, maxQty)
+	}
+
+	if pair.MinNotional > 0 && price > 0 {
+		notional := qty * price
+		if pair.ContractVal > 0 && pair.ContractValCurrency == pair.BaseSymbol {
+			notional *= pair.ContractVal
+		}
+		if notional < pair.MinNotional {
+			return fmt.Errorf("%w: %v < %v", ErrNotionalTooSmall, notional, pair.MinNotional)
+		}
+	}
+
+	return nil
+}
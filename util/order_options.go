@@ -0,0 +1,72 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/shadowors/goex/v2/model"
+)
+
+var ErrUnsupportedOption = errors.New("unsupported order option")
+
+// OrderOptions 类型化的下单参数, 见model.OptionParameter的TimeInForce/PostOnly等方法
+type OrderOptions struct {
+	TimeInForce   model.TimeInForce //GTX已转换为PostOnly, 为空表示GTC
+	PostOnly      bool
+	ReduceOnly    bool
+	ClosePosition bool
+	STPMode       model.STPMode
+	PriceMatch    model.PriceMatch
+}
+
+// PopOrderOptions 从params中取出类型化的下单参数(取出后删除), 并校验互相冲突的组合
+func PopOrderOptions(params *url.Values) (opts OrderOptions, err error) {
+	pop := func(key string) string {
+		v := params.Get(key)
+		params.Del(key)
+		return v
+	}
+
+	opts.TimeInForce = model.TimeInForce(pop(model.Time_In_Force__Opt_Key))
+	opts.PostOnly = pop(model.Post_Only__Opt_Key) == "true"
+	opts.ReduceOnly = pop(model.Reduce_Only__Opt_Key) == "true"
+	opts.ClosePosition = pop(model.Close_Position__Opt_Key) == "true"
+	opts.STPMode = model.STPMode(pop(model.Self_Trade_Prevention__Opt_Key))
+	opts.PriceMatch = model.PriceMatch(pop(model.Price_Match__Opt_Key))
+
+	switch opts.TimeInForce {
+	case "", model.TimeInForce_GTC:
+		opts.TimeInForce = ""
+	case model.TimeInForce_GTX:
+		opts.TimeInForce = ""
+		opts.PostOnly = true
+	case model.TimeInForce_IOC, model.TimeInForce_FOK:
+		if opts.PostOnly {
+			return opts, fmt.Errorf("%w: post only with time in force %s", ErrUnsupportedOption, opts.TimeInForce)
+		}
+	default:
+		return opts, fmt.Errorf("%w: time in force %s", ErrUnsupportedOption, opts.TimeInForce)
+	}
+
+	switch opts.STPMode {
+	case "", model.STPMode_CancelTaker, model.STPMode_CancelMaker, model.STPMode_CancelBoth:
+	default:
+		return opts, fmt.Errorf("%w: self trade prevention mode %s", ErrUnsupportedOption, opts.STPMode)
+	}
+
+	if opts.PriceMatch != "" && opts.PostOnly {
+		return opts, fmt.Errorf("%w: price match with post only", ErrUnsupportedOption)
+	}
+
+	if opts.ClosePosition && opts.ReduceOnly {
+		return opts, fmt.Errorf("%w: close position with reduce only", ErrUnsupportedOption)
+	}
+
+	return opts, nil
+}
+
+// UnsupportedOption 交易所不支持的参数
+func UnsupportedOption(exchange, format string, args ...any) error {
+	return fmt.Errorf("%w: %s %s", ErrUnsupportedOption, exchange, fmt.Sprintf(format, args...))
+}
@@ -1,13 +1,21 @@
 package util
 
 import (
+	"crypto"
+	"crypto/ed25519"
 	"crypto/hmac"
 	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
 )
 
 func MD5Sign(secret, params string) (string, error) {
@@ -86,3 +94,35 @@ func HmacSHA512Base64Sign(hmacKey string, data string) string {
 
 	return base64.StdEncoding.EncodeToString(hashHmacBytes)
 }
+
+func Ed25519Base64Sign(key ed25519.PrivateKey, params string) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte(params)))
+}
+
+// RSASHA256Base64Sign RSASSA-PKCS1-v1_5 + SHA256
+func RSASHA256Base64Sign(key *rsa.PrivateKey, params string) (string, error) {
+	hashed := sha256.Sum256([]byte(params))
+	sign, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sign), nil
+}
+
+// ParsePrivateKeyPEM 解析PEM格式私钥, 支持PKCS8(Ed25519/RSA/ECDSA)和PKCS1(RSA)
+func ParsePrivateKeyPEM(pemData []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("invalid PEM private key")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
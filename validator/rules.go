@@ -0,0 +1,278 @@
+package validator
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/shadowors/goex/v2/model"
+	"github.com/shadowors/goex/v2/util"
+)
+
+// PriceFunc 获取参考价格(最新成交价/标记价格), 可用CachedPrice包装避免每次下单都请求交易所
+type PriceFunc func(pair model.CurrencyPair) (float64, error)
+
+// PriceLimitFunc 获取限价单价格限制, 如OKx /api/v5/public/price-limit
+type PriceLimitFunc func(pair model.CurrencyPair) (*model.PriceLimit, error)
+
+// PositionsFunc 获取当前持仓
+type PositionsFunc func(pair model.CurrencyPair) ([]model.FuturesPosition, error)
+
+// QtyLimit 数量不能小于MinQty, 不能大于MaxQty(市价单为MarketQty)
+func QtyLimit() Validator {
+	return ValidatorFunc(func(req *model.OrderRequest) error {
+		pair := req.Pair
+		if req.Qty <= 0 || (pair.MinQty > 0 && req.Qty < pair.MinQty) {
+			return newError(Rule_MinQty, req, "qty %v less than min qty %v", req.Qty, pair.MinQty)
+		}
+
+		maxQty := pair.MaxQty
+		if req.OrderTy == model.OrderType_Market && pair.MarketQty > 0 {
+			maxQty = pair.MarketQty
+		}
+		if maxQty > 0 && req.Qty > maxQty {
+			return newError(Rule_MaxQty, req, "qty %v greater than max qty %v", req.Qty, maxQty)
+		}
+
+		return nil
+	})
+}
+
+// MinNotional 下单金额不能小于MinNotional, 没有价格(市价单)时不校验
+func MinNotional() Validator {
+	return ValidatorFunc(func(req *model.OrderRequest) error {
+		pair := req.Pair
+		if pair.MinNotional <= 0 || req.Price <= 0 {
+			return nil
+		}
+
+		notional := req.Qty * req.Price
+		if pair.ContractVal > 0 && pair.ContractValCurrency == pair.BaseSymbol {
+			notional *= pair.ContractVal
+		}
+
+		if notional < pair.MinNotional {
+			return newError(Rule_MinNotional, req, "notional %v less than min notional %v", notional, pair.MinNotional)
+		}
+
+		return nil
+	})
+}
+
+// PriceLimit 限价单买价不能高于BuyLmt, 卖价不能低于SellLmt
+func PriceLimit(fn PriceLimitFunc) Validator {
+	return ValidatorFunc(func(req *model.OrderRequest) error {
+		if req.Price <= 0 || req.OrderTy == model.OrderType_Market {
+			return nil
+		}
+
+		lmt, err := fn(req.Pair)
+		if err != nil {
+			return err
+		}
+
+		if util.IsBuy(req.Side) && lmt.BuyLmt > 0 && req.Price > lmt.BuyLmt {
+			return newError(Rule_PriceLimit, req, "buy price %v greater than %v", req.Price, lmt.BuyLmt)
+		}
+
+		if !util.IsBuy(req.Side) && lmt.SellLmt > 0 && req.Price < lmt.SellLmt {
+			return newError(Rule_PriceLimit, req, "sell price %v less than %v", req.Price, lmt.SellLmt)
+		}
+
+		return nil
+	})
+}
+
+// PercentPrice 限价单价格必须在 [ref*MultiplierDown, ref*MultiplierUp] 区间内, 如币安PERCENT_PRICE
+func PercentPrice(ref PriceFunc) Validator {
+	return ValidatorFunc(func(req *model.OrderRequest) error {
+		pair := req.Pair
+		if req.Price <= 0 || req.OrderTy == model.OrderType_Market ||
+			(pair.MultiplierUp <= 0 && pair.MultiplierDown <= 0) {
+			return nil
+		}
+
+		refPrice, err := ref(pair)
+		if err != nil {
+			return err
+		}
+
+		if pair.MultiplierUp > 0 && req.Price > refPrice*pair.MultiplierUp {
+			return newError(Rule_PriceLimit, req, "price %v greater than %v", req.Price, refPrice*pair.MultiplierUp)
+		}
+
+		if pair.MultiplierDown > 0 && req.Price < refPrice*pair.MultiplierDown {
+			return newError(Rule_PriceLimit, req, "price %v less than %v", req.Price, refPrice*pair.MultiplierDown)
+		}
+
+		return nil
+	})
+}
+
+// ReduceOnly 平仓单或只减仓单的数量不能超过对应方向的持仓
+func ReduceOnly(positions PositionsFunc) Validator {
+	return ValidatorFunc(func(req *model.OrderRequest) error {
+		posSide, ok := reducedPosSide(req)
+		if !ok {
+			return nil
+		}
+
+		posList, err := positions(req.Pair)
+		if err != nil {
+			return err
+		}
+
+		posQty := sumPosQty(posList, posSide)
+		if req.Qty > posQty {
+			return newError(Rule_ReduceOnly, req, "qty %v greater than position %v", req.Qty, posQty)
+		}
+
+		return nil
+	})
+}
+
+// MaxPosition 开仓后同方向持仓不能超过maxQty
+func MaxPosition(maxQty float64, positions PositionsFunc) Validator {
+	return ValidatorFunc(func(req *model.OrderRequest) error {
+		if req.Side != model.Futures_OpenBuy && req.Side != model.Futures_OpenSell {
+			return nil
+		}
+
+		posList, err := positions(req.Pair)
+		if err != nil {
+			return err
+		}
+
+		posQty := sumPosQty(posList, req.Side)
+		if posQty+req.Qty > maxQty {
+			return newError(Rule_MaxPosition, req, "position %v + qty %v greater than %v", posQty, req.Qty, maxQty)
+		}
+
+		return nil
+	})
+}
+
+// FatFinger 限价单价格偏离参考价格超过maxDeviation(如0.05表示5%)时拒绝
+func FatFinger(maxDeviation float64, ref PriceFunc) Validator {
+	return ValidatorFunc(func(req *model.OrderRequest) error {
+		if req.Price <= 0 || req.OrderTy == model.OrderType_Market {
+			return nil
+		}
+
+		refPrice, err := ref(req.Pair)
+		if err != nil {
+			return err
+		}
+
+		if refPrice <= 0 {
+			return nil
+		}
+
+		deviation := math.Abs(req.Price-refPrice) / refPrice
+		if deviation > maxDeviation {
+			return newError(Rule_FatFinger, req, "price %v deviates %.2f%% from %v", req.Price, deviation*100, refPrice)
+		}
+
+		return nil
+	})
+}
+
+// CachedPrice 缓存参考价格ttl时长
+func CachedPrice(fn PriceFunc, ttl time.Duration) PriceFunc {
+	c := newTTLCache(ttl)
+	return func(pair model.CurrencyPair) (float64, error) {
+		v, err := c.get(pair.Symbol, func() (interface{}, error) {
+			return fn(pair)
+		})
+		if err != nil {
+			return 0, err
+		}
+		return v.(float64), nil
+	}
+}
+
+// CachedPriceLimit 缓存价格限制ttl时长
+func CachedPriceLimit(fn PriceLimitFunc, ttl time.Duration) PriceLimitFunc {
+	c := newTTLCache(ttl)
+	return func(pair model.CurrencyPair) (*model.PriceLimit, error) {
+		v, err := c.get(pair.Symbol, func() (interface{}, error) {
+			return fn(pair)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return v.(*model.PriceLimit), nil
+	}
+}
+
+// reducedPosSide 返回订单将减少的持仓方向
+func reducedPosSide(req *model.OrderRequest) (model.OrderSide, bool) {
+	switch req.Side {
+	case model.Futures_CloseBuy:
+		return model.Futures_OpenBuy, true
+	case model.Futures_CloseSell:
+		return model.Futures_OpenSell, true
+	}
+
+	if req.Opt(model.Reduce_Only__Opt_Key) != "true" && req.Opt("reduceOnly") != "true" {
+		return "", false
+	}
+
+	if req.Side == model.Spot_Sell {
+		return model.Futures_OpenBuy, true
+	}
+
+	return model.Futures_OpenSell, true
+}
+
+// sumPosQty posSide方向的持仓张数, 单向持仓(okx net模式)按Qty的符号区分多空
+func sumPosQty(positions []model.FuturesPosition, posSide model.OrderSide) float64 {
+	var long, short float64
+	for _, pos := range positions {
+		if qty := util.PositionQty(pos); qty > 0 {
+			long += qty
+		} else {
+			short -= qty
+		}
+	}
+	if posSide == model.Futures_OpenSell {
+		return short
+	}
+	return long
+}
+
+type ttlCache struct {
+	ttl time.Duration
+	mu  sync.Mutex
+	m   map[string]ttlCacheItem
+}
+
+type ttlCacheItem struct {
+	v  interface{}
+	at time.Time
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	return &ttlCache{ttl: ttl, m: make(map[string]ttlCacheItem, 8)}
+}
+
+func (c *ttlCache) get(k string, load func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	item, ok := c.m[k]
+	c.mu.Unlock()
+
+	if ok && time.Since(item.at) < c.ttl {
+		return item.v, nil
+	}
+
+	v, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.m[k] = ttlCacheItem{v: v, at: time.Now()}
+	c.mu.Unlock()
+
+	return v, nil
+}
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/shadowors/goex/v2/model"
+)
+
+// Validator 下单前校验, 返回非nil error时订单不会发送到交易所
+type Validator interface {
+	Validate(req *model.OrderRequest) error
+}
+
+type ValidatorFunc func(req *model.OrderRequest) error
+
+func (fn ValidatorFunc) Validate(req *model.OrderRequest) error {
+	return fn(req)
+}
+
+// Chain 按顺序执行, 遇到第一个错误即返回
+type Chain []Validator
+
+func (c Chain) Validate(req *model.OrderRequest) error {
+	for _, v := range c {
+		if v == nil {
+			continue
+		}
+		if err := v.Validate(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Defaults 所有交易所私有接口默认启用的校验: 数量上下限、最小下单金额
+func Defaults() []Validator {
+	return []Validator{QtyLimit(), MinNotional()}
+}
+
+type Rule string
+
+const (
+	Rule_MinQty      Rule = "min_qty"
+	Rule_MaxQty      Rule = "max_qty"
+	Rule_MinNotional Rule = "min_notional"
+	Rule_PriceLimit  Rule = "price_limit"
+	Rule_ReduceOnly  Rule = "reduce_only"
+	Rule_MaxPosition Rule = "max_position"
+	Rule_FatFinger   Rule = "fat_finger"
+)
+
+// Error 校验失败的错误类型, 可通过errors.Is(err, ErrMinQty)等判断具体规则
+type Error struct {
+	Rule   Rule
+	Symbol string
+	Msg    string
+}
+
+var (
+	ErrMinQty      = &Error{Rule: Rule_MinQty}
+	ErrMaxQty      = &Error{Rule: Rule_MaxQty}
+	ErrMinNotional = &Error{Rule: Rule_MinNotional}
+	ErrPriceLimit  = &Error{Rule: Rule_PriceLimit}
+	ErrReduceOnly  = &Error{Rule: Rule_ReduceOnly}
+	ErrMaxPosition = &Error{Rule: Rule_MaxPosition}
+	ErrFatFinger   = &Error{Rule: Rule_FatFinger}
+)
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("[validator] %s %s: %s", e.Symbol, e.Rule, e.Msg)
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Rule == e.Rule
+}
+
+func newError(rule Rule, req *model.OrderRequest, format string, args ...interface{}) *Error {
+	return &Error{Rule: rule, Symbol: req.Pair.Symbol, Msg: fmt.Sprintf(format, args...)}
+}